@@ -0,0 +1,123 @@
+package trie
+
+import "bytes"
+
+// Clone returns deep copy of trie: all nodes and prefixes are copied, so clone doesn't share anything with original.
+// If copyValue is not nil - it is used to copy values (e.g. for values containing pointers, slices or maps),
+// otherwise values are copied by assignment.
+func (t *Trie[T]) Clone(copyValue func(T) T) *Trie[T] {
+	if t == nil {
+		return nil
+	}
+
+	var res = &Trie[T]{Prefix: bytes.Clone(t.Prefix)}
+	if t.Value != nil {
+		var value = *t.Value
+		if copyValue != nil {
+			value = copyValue(value)
+		}
+		res.Value = &value
+	}
+	if t.Children != nil {
+		res.Children = &[256]*Trie[T]{}
+		for i, c := range t.Children {
+			if c != nil {
+				res.Children[i] = c.Clone(copyValue)
+			}
+		}
+	}
+	return res
+}
+
+// Equal reports whether both tries contain the same keys with equal values (according to eq).
+// Only logical content is compared, not node layout: tries built differently (e.g. literals or results of SubTrie)
+// are equal if they store the same entries. Both tries are walked together only once.
+// Subtrees shared by both tries are not compared.
+func (t *Trie[T]) Equal(other *Trie[T], eq func(a, b T) bool) bool {
+	return equalAt(t, 0, other, 0, eq, false)
+}
+
+// equalAt compares trie a starting from a.Prefix[aOff:] with trie b starting from b.Prefix[bOff:].
+// If swapped is true - a and b are passed to eq in reverse order.
+func equalAt[T any](a *Trie[T], aOff int, b *Trie[T], bOff int, eq func(a, b T) bool, swapped bool) bool {
+	if a == nil || b == nil {
+		return a.isEmpty() && b.isEmpty()
+	}
+	if a == b && aOff == bOff {
+		return true
+	}
+
+	var aPrefix, bPrefix = a.Prefix[aOff:], b.Prefix[bOff:]
+	ind := 0
+	for ind < len(aPrefix) && ind < len(bPrefix) && aPrefix[ind] == bPrefix[ind] {
+		ind++
+	}
+
+	switch {
+	case ind < len(aPrefix) && ind < len(bPrefix):
+		// prefixes diverged - there can't be common keys
+		return a.isEmpty() && b.isEmpty()
+	case ind < len(aPrefix):
+		// b is exhausted - continue with a as the longer one
+		return equalAt(b, bOff+ind, a, aOff+ind, eq, !swapped)
+	case ind < len(bPrefix):
+		// a is exhausted - b has no value here and only one child of a can be equal to the rest of b
+		if a.Value != nil {
+			return false
+		}
+		var next = int(bPrefix[ind])
+		if a.Children != nil {
+			for i, c := range a.Children {
+				if i != next && !c.isEmpty() {
+					return false
+				}
+			}
+			return equalAt(a.Children[next], 0, b, bOff+ind, eq, swapped)
+		}
+		return b.isEmpty()
+	}
+
+	// both prefixes are exhausted
+	if (a.Value == nil) != (b.Value == nil) {
+		return false
+	}
+	if a.Value != nil && a.Value != b.Value {
+		if swapped && !eq(*b.Value, *a.Value) || !swapped && !eq(*a.Value, *b.Value) {
+			return false
+		}
+	}
+	if a.Children == b.Children {
+		return true
+	}
+	for i := 0; i < 256; i++ {
+		var aChild, bChild *Trie[T]
+		if a.Children != nil {
+			aChild = a.Children[i]
+		}
+		if b.Children != nil {
+			bChild = b.Children[i]
+		}
+		if !equalAt(aChild, 0, bChild, 0, eq, swapped) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmpty reports whether there are no values in trie
+func (t *Trie[T]) isEmpty() bool {
+	if t == nil {
+		return true
+	}
+	if t.Value != nil {
+		return false
+	}
+	if t.Children != nil {
+		for _, c := range t.Children {
+			if !c.isEmpty() {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package trie
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTrie_Clone(t *testing.T) {
+	tr := BuildFromMap(map[string][]int{
+		"":               {0},
+		"/api/user":      {1},
+		"/api/user/list": {2, 3},
+		"/api/group":     {4},
+	})
+
+	clone := tr.Clone(slices.Clone[[]int])
+	if !clone.Equal(tr, slices.Equal[[]int]) || clone.String() != tr.String() {
+		t.Fatalf("clone differs:\nexpected\n%s\ngot\n%s\n", tr, clone)
+	}
+
+	// modifications of clone don't affect original
+	clone.PutString("/api/articles", []int{5})
+	clone.DeleteString("/api/user")
+	v, _ := clone.GetByString("/api/user/list")
+	v[0] = 100
+
+	if v, _ := tr.GetByString("/api/user/list"); v[0] != 2 {
+		t.Errorf("value was not copied")
+	}
+	if tr.Count() != 4 {
+		t.Errorf("original was modified:\n%s", tr)
+	}
+	if (*Trie[int])(nil).Clone(nil) != nil {
+		t.Errorf("clone of nil should be nil")
+	}
+}
+
+func TestTrie_Equal(t *testing.T) {
+	eq := func(a, b string) bool { return a == b }
+
+	tr := BuildFromMap(map[string]string{
+		"/api/user":      "user",
+		"/api/user/list": "users list",
+		"/api/group":     "group",
+	})
+
+	// the same content with different layout
+	literal := &T{Prefix: []byte("/"), Children: &[256]*T{
+		'a': {Prefix: []byte("api"), Children: &[256]*T{
+			'/': {Prefix: []byte("/"), Children: &[256]*T{
+				'g': {Prefix: []byte("group"), Value: ptr("group")},
+				'u': {Prefix: []byte("u"), Children: &[256]*T{
+					's': {Prefix: []byte("ser"), Value: ptr("user"), Children: &[256]*T{
+						'/': {Prefix: []byte("/list"), Value: ptr("users list")},
+						'!': {Prefix: []byte("!")}, // empty node
+					}},
+				}},
+			}},
+		}},
+	}}
+
+	if !tr.Equal(literal, eq) || !literal.Equal(tr, eq) {
+		t.Errorf("tries with the same content should be equal")
+	}
+	if !tr.Equal(tr, eq) {
+		t.Errorf("trie should be equal to itself")
+	}
+	if !(&T{}).Equal(nil, eq) {
+		t.Errorf("empty tries should be equal")
+	}
+
+	sub, _ := tr.SubTrie([]byte("/api/"), false)
+	if !sub.Equal(BuildFromMap(map[string]string{"user": "user", "user/list": "users list", "group": "group"}), eq) {
+		t.Errorf("SubTrie should be equal to trie built from it's content")
+	}
+
+	literal.Children['a'].Children['/'].Children['g'].Value = ptr("other")
+	if tr.Equal(literal, eq) || literal.Equal(tr, eq) {
+		t.Errorf("tries with different values shouldn't be equal")
+	}
+
+	other := tr.Clone(nil)
+	other.PutString("/api/use", "use")
+	if tr.Equal(other, eq) || other.Equal(tr, eq) {
+		t.Errorf("tries with different keys shouldn't be equal")
+	}
+}
@@ -0,0 +1,254 @@
+package trie
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	"math"
+	"sync/atomic"
+)
+
+// ErrStale is returned by Completer when trie was modified directly (not through Completer),
+// so cached scores can be wrong. Call Completer.Refresh to rebuild them.
+var ErrStale = errors.New("trie: modified bypassing completer, refresh is required")
+
+// Completion is a single result of Completer.Complete
+type Completion[T any] struct {
+	Key   []byte
+	Value T
+	Score float64
+}
+
+// Completer returns entries with the highest scores among keys starting with prefix (top-k autocompletion).
+//
+// Score function is bound to Completer, and the best score of every subtree is cached, so search visits only
+// subtrees that can contain some of the best entries instead of the whole SubTrie.
+// Cache is kept aside of trie (it takes memory only for tries that need it) and is built by NewCompleter.
+//
+// Use Put and Delete of Completer to modify trie: they update trie and cache along the path to the key.
+// Modifications made directly through trie are detected (the same way as for Cursor): after them Complete
+// returns ErrStale until cache is rebuilt with Refresh.
+//
+// Complete doesn't write anything, so it can be called concurrently. Put, Delete and Refresh
+// must not run concurrently with any other method or with modifications of trie.
+type Completer[T any] struct {
+	trie  *Trie[T]
+	score func(T) float64
+
+	// scores contains the best score of every node of trie (-Inf if there are no values in subtree)
+	scores map[*Trie[T]]float64
+	// mods is a modification counter of trie, and seen is it's value for which scores were calculated
+	mods *uint64
+	seen uint64
+}
+
+// NewCompleter creates Completer that ranks values of t with score (it should be pure function and should not return NaN).
+// It walks the whole trie to build cache of scores.
+func NewCompleter[T any](t *Trie[T], score func(T) float64) *Completer[T] {
+	var c = &Completer[T]{
+		trie:  t,
+		score: score,
+		mods:  t.watch(),
+	}
+	c.Refresh()
+	return c
+}
+
+// Refresh rebuilds cache of scores. It should be called after trie was modified directly
+// (not with Put or Delete of Completer).
+func (c *Completer[T]) Refresh() {
+	c.scores = make(map[*Trie[T]]float64)
+	bestScore(c.trie, c.scores, c.score)
+	c.seen = atomic.LoadUint64(c.mods)
+}
+
+// PutString is a convenience method for Put
+func (c *Completer[T]) PutString(key string, value T) (oldValue T) {
+	return c.Put([]byte(key), value)
+}
+
+// Put stores value in trie (see Trie.Put) and updates cached scores along the path to the key.
+func (c *Completer[T]) Put(key []byte, value T) (oldValue T) {
+	var fresh = c.fresh()
+	oldValue = c.trie.Put(key, value)
+	if fresh {
+		// nodes created by split are not cached yet and would be calculated too
+		for _, node := range c.path(key) {
+			delete(c.scores, node)
+		}
+		c.update()
+	}
+	return oldValue
+}
+
+// DeleteString is a convenience method for Delete
+func (c *Completer[T]) DeleteString(key string) (oldValue T, ok bool) {
+	return c.Delete([]byte(key))
+}
+
+// Delete removes value from trie (see Trie.Delete) and updates cached scores along the path to the key.
+func (c *Completer[T]) Delete(key []byte) (oldValue T, ok bool) {
+	var fresh = c.fresh()
+	var affected []*Trie[T]
+	if fresh {
+		// compaction can change or drop nodes along the path and their children
+		for _, node := range c.path(key) {
+			affected = append(affected, node)
+			if node.Children != nil {
+				for _, child := range node.Children {
+					if child != nil {
+						affected = append(affected, child)
+					}
+				}
+			}
+		}
+	}
+	oldValue, ok = c.trie.Delete(key)
+	if ok && fresh {
+		for _, node := range affected {
+			delete(c.scores, node)
+		}
+		c.update()
+	}
+	return oldValue, ok
+}
+
+// CompleteString is a convenience method for Complete
+func (c *Completer[T]) CompleteString(prefix string, k int) ([]Completion[T], error) {
+	return c.Complete([]byte(prefix), k)
+}
+
+// Complete returns k entries with the highest scores among keys starting with prefix
+// (ordered by score descending, entries with equal scores - by key).
+//
+//	tr := {"go": 10, "golang": 50, "gopher": 30, "rust": 40}
+//
+//	NewCompleter(tr, score).Complete("go", 2)
+//	-> {"golang", 50, 50}, {"gopher", 30, 30}
+//
+// Returns ErrStale if trie was modified bypassing Completer.
+func (c *Completer[T]) Complete(prefix []byte, k int) ([]Completion[T], error) {
+	if !c.fresh() {
+		return nil, ErrStale
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+	node, off, ok := c.trie.locate(prefix)
+	if !ok {
+		return nil, nil
+	}
+
+	var key = make([]byte, 0, len(prefix)+len(node.Prefix)-off)
+	key = append(append(key, prefix...), node.Prefix[off:]...)
+
+	// fresh cache contains every node of trie
+	var queue = &completionQueue[T]{{node: node, key: key, score: c.scores[node]}}
+	var res = make([]Completion[T], 0, k)
+	for queue.Len() > 0 && len(res) < k {
+		var item = heap.Pop(queue).(completionItem[T])
+		if item.node == nil {
+			res = append(res, Completion[T]{Key: item.key, Value: item.value, Score: item.score})
+			continue
+		}
+
+		// expand node: it's own value and children are added as separate items
+		var n = item.node
+		if n.Value != nil {
+			heap.Push(queue, completionItem[T]{key: item.key, value: *n.Value, score: c.score(*n.Value)})
+		}
+		if n.Children != nil {
+			for _, child := range n.Children {
+				if child == nil || child.isEmpty() {
+					continue
+				}
+				var childKey = make([]byte, 0, len(item.key)+len(child.Prefix))
+				childKey = append(append(childKey, item.key...), child.Prefix...)
+				heap.Push(queue, completionItem[T]{node: child, key: childKey, score: c.scores[child]})
+			}
+		}
+	}
+	return res, nil
+}
+
+// fresh reports whether trie was not modified after scores were calculated
+func (c *Completer[T]) fresh() bool {
+	return atomic.LoadUint64(c.mods) == c.seen
+}
+
+// update calculates scores of nodes that are missing in cache and accepts current state of trie
+func (c *Completer[T]) update() {
+	bestScore(c.trie, c.scores, c.score)
+	c.seen = atomic.LoadUint64(c.mods)
+}
+
+// path returns nodes from the root to the node where key ends (or where search of key stops)
+func (c *Completer[T]) path(key []byte) (path []*Trie[T]) {
+	var node = c.trie
+	for node != nil {
+		path = append(path, node)
+		ind := 0
+		for ind < len(node.Prefix) && ind < len(key) && node.Prefix[ind] == key[ind] {
+			ind++
+		}
+		if ind < len(node.Prefix) || ind == len(key) {
+			return path
+		}
+		key = key[ind:]
+		node = node.child(int(key[0]))
+	}
+	return path
+}
+
+// bestScore returns the best score of values in subtree (-Inf if there are no values).
+// Scores are taken from scores, and missing ones are calculated and saved there.
+func bestScore[T any](t *Trie[T], scores map[*Trie[T]]float64, score func(T) float64) float64 {
+	if best, ok := scores[t]; ok {
+		return best
+	}
+	var best = math.Inf(-1)
+	if t.Value != nil {
+		best = score(*t.Value)
+	}
+	if t.Children != nil {
+		for _, c := range t.Children {
+			if c != nil {
+				best = max(best, bestScore(c, scores, score))
+			}
+		}
+	}
+	scores[t] = best
+	return best
+}
+
+// completionItem is either the whole subtree (node is not nil) or a single entry.
+// Key of subtree is not greater than any key inside it, so order by score and key is kept when subtree is expanded.
+type completionItem[T any] struct {
+	node  *Trie[T]
+	key   []byte
+	value T
+	score float64
+}
+
+// completionQueue is a max-heap by score (and min-heap by key for equal scores)
+type completionQueue[T any] []completionItem[T]
+
+func (q completionQueue[T]) Len() int { return len(q) }
+
+func (q completionQueue[T]) Less(i, j int) bool {
+	if q[i].score != q[j].score {
+		return q[i].score > q[j].score
+	}
+	return bytes.Compare(q[i].key, q[j].key) < 0
+}
+
+func (q completionQueue[T]) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *completionQueue[T]) Push(x any) { *q = append(*q, x.(completionItem[T])) }
+
+func (q *completionQueue[T]) Pop() any {
+	var old = *q
+	var item = old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,186 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func completeBruteForce(tr *Trie[int], prefix []byte, k int, score func(int) float64) []Completion[int] {
+	var all []Completion[int]
+	for key, value := range tr.All() {
+		if bytes.HasPrefix(key, prefix) {
+			all = append(all, Completion[int]{Key: bytes.Clone(key), Value: value, Score: score(value)})
+		}
+	}
+	slices.SortStableFunc(all, func(a, b Completion[int]) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return bytes.Compare(a.Key, b.Key)
+	})
+	return all[:min(k, len(all))]
+}
+
+func randomKey(maxLen int) []byte {
+	var key = make([]byte, rand.Intn(maxLen+1))
+	for i := range key {
+		key[i] = "abc"[rand.Intn(3)]
+	}
+	return key
+}
+
+// checkScores checks that cache of fresh completer contains correct scores of all nodes of trie and nothing else
+func checkScores(t *testing.T, c *Completer[int]) {
+	var count = 0
+	var walk func(node *Trie[int]) float64
+	walk = func(node *Trie[int]) float64 {
+		count++
+		var best = math.Inf(-1)
+		if node.Value != nil {
+			best = c.score(*node.Value)
+		}
+		if node.Children != nil {
+			for _, child := range node.Children {
+				if child != nil {
+					best = max(best, walk(child))
+				}
+			}
+		}
+		if cached, ok := c.scores[node]; !ok || cached != best {
+			t.Fatalf("%v: node %q has score (%v, %v) expected %v", c.trie, node.Prefix, cached, ok, best)
+		}
+		return best
+	}
+	walk(c.trie)
+	if count != len(c.scores) {
+		t.Fatalf("%v: cache contains %d nodes, but trie has %d", c.trie, len(c.scores), count)
+	}
+}
+
+func TestCompleter__Random(t *testing.T) {
+	var score = func(v int) float64 { return float64(v % 7) }
+
+	for i := 0; i < 300; i++ {
+		var tr = &Trie[int]{}
+		for j := 0; j < rand.Intn(30); j++ {
+			tr.Put(randomKey(4), rand.Intn(100))
+		}
+		var c = NewCompleter(tr, score)
+
+		for step := 0; step < 10; step++ {
+			var prefix, k = randomKey(2), rand.Intn(6)
+			var res, err = c.Complete(prefix, k)
+			if !c.fresh() {
+				if err != ErrStale {
+					t.Fatalf("%v Complete(%q, %d): expected ErrStale, got %v", c.trie, prefix, k, err)
+				}
+			} else if expected := completeBruteForce(c.trie, prefix, k, score); err != nil ||
+				fmt.Sprint(res) != fmt.Sprint(expected) && !(len(res) == 0 && len(expected) == 0) {
+				t.Fatalf("%v Complete(%q, %d): got %v %v expected %v", c.trie, prefix, k, res, err, expected)
+			}
+
+			switch rand.Intn(6) {
+			case 0, 1:
+				c.Put(randomKey(4), rand.Intn(100))
+			case 2, 3:
+				c.Delete(randomKey(4))
+			case 4:
+				// direct modification - completer should notice it
+				c.trie.DeletePrefix(randomKey(2))
+			case 5:
+				c.Refresh()
+			}
+			if c.fresh() {
+				checkScores(t, c)
+			}
+		}
+	}
+}
+
+func TestCompleter(t *testing.T) {
+	tr := BuildFromMap(map[string]int{"go": 10, "golang": 50, "gopher": 30, "rust": 40})
+	c := NewCompleter(tr, func(v int) float64 { return float64(v) })
+
+	if res, _ := c.CompleteString("go", 2); fmt.Sprint(res) != fmt.Sprint([]Completion[int]{
+		{Key: []byte("golang"), Value: 50, Score: 50},
+		{Key: []byte("gopher"), Value: 30, Score: 30},
+	}) {
+		t.Errorf("unexpected result %v", res)
+	}
+	if res, err := c.CompleteString("x", 2); len(res) != 0 || err != nil {
+		t.Errorf("unexpected result %v %v", res, err)
+	}
+	if res, err := c.CompleteString("", 0); len(res) != 0 || err != nil {
+		t.Errorf("unexpected result %v %v", res, err)
+	}
+
+	// direct modification makes cache stale until refresh
+	tr.PutString("gopls", 100)
+	if res, err := c.CompleteString("go", 1); err != ErrStale {
+		t.Errorf("expected ErrStale, got %v %v", res, err)
+	}
+	c.Refresh()
+	if res, err := c.CompleteString("go", 1); err != nil || len(res) != 1 || string(res[0].Key) != "gopls" {
+		t.Errorf("unexpected result %v %v", res, err)
+	}
+
+	// completers with different score functions don't interfere
+	tr = BuildFromMap(map[string]int{"xa": 1, "xb": 2, "y": 5})
+	negative := NewCompleter(tr, func(v int) float64 { return -float64(v) })
+	positive := NewCompleter(tr, func(v int) float64 { return float64(v) })
+	if res, _ := negative.CompleteString("", 1); len(res) != 1 || string(res[0].Key) != "xa" {
+		t.Errorf("unexpected result %v", res)
+	}
+	if res, _ := positive.CompleteString("", 1); len(res) != 1 || string(res[0].Key) != "y" {
+		t.Errorf("unexpected result %v", res)
+	}
+
+	// NewCompleter and Complete must not race with other reads (checked with -race)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			NewCompleter(tr, func(v int) float64 { return float64(v) })
+			if _, err := positive.CompleteString("x", 1); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func ExampleCompleter() {
+	popularity := BuildFromMap(map[string]int{
+		"apple":      120,
+		"apple pie":  40,
+		"apricot":    75,
+		"avocado":    300,
+		"applesauce": 75,
+		"banana":     500,
+	})
+	c := NewCompleter(popularity, func(v int) float64 { return float64(v) })
+
+	top, _ := c.CompleteString("ap", 3)
+	for _, res := range top {
+		fmt.Printf("%s %v\n", res.Key, res.Score)
+	}
+
+	// cache is updated along the path to the key
+	c.PutString("apple pie", 1000)
+	top, _ = c.CompleteString("a", 1)
+	fmt.Printf("%s\n", top[0].Key)
+	// Output:
+	// apple 120
+	// applesauce 75
+	// apricot 75
+	// apple pie
+}
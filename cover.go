@@ -0,0 +1,96 @@
+package trie
+
+import "iter"
+
+// Shadowed returns iterator over keys (with values) that have a shorter stored key as a prefix.
+// Such keys are redundant if trie is used to check whether input has any stored prefix.
+// Order and key reuse are the same as for All.
+//
+//	tr := {"/api/": v0, "/api/user": v1, "/static/": v2}
+//
+//	tr.Shadowed()
+//	-> ("/api/user", v1)
+func (t *Trie[T]) Shadowed() iter.Seq2[[]byte, T] {
+	return func(yield func([]byte, T) bool) {
+		if t != nil {
+			t.shadowed(make([]byte, 0, 64), false, yield)
+		}
+	}
+}
+
+func (t *Trie[T]) shadowed(prefix []byte, covered bool, yield func([]byte, T) bool) bool {
+	prefix = append(prefix, t.Prefix...)
+	if t.Value != nil {
+		if covered && !yield(prefix, *t.Value) {
+			return false
+		}
+		covered = true
+	}
+	if t.Children != nil {
+		for _, c := range t.Children {
+			if c != nil && !c.shadowed(prefix, covered, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MinimalCover returns new trie without shadowed keys (see Shadowed).
+// It is the smallest set of prefixes that matches the same inputs when any matched prefix is enough
+// (for example, when SearchPrefixIn is used only to check ok).
+//
+//	tr := {"/api/": v0, "/api/user": v1, "/static/": v2}
+//
+//	tr.MinimalCover()
+//	-> {"/api/": v0, "/static/": v2}
+func (t *Trie[T]) MinimalCover() *Trie[T] {
+	return orEmpty(minimalCover(t))
+}
+
+func minimalCover[T any](t *Trie[T]) *Trie[T] {
+	if t == nil {
+		return nil
+	}
+	if t.Value != nil {
+		// all children are shadowed by current key
+		return &Trie[T]{Prefix: t.Prefix, Value: t.Value}
+	}
+	var res = &Trie[T]{Prefix: t.Prefix}
+	if t.Children != nil {
+		for i, c := range t.Children {
+			res.setChild(i, minimalCover(c))
+		}
+	}
+	return res.normalized()
+}
+
+// CoverAggregate returns new trie without keys whose values are equal (according to eq)
+// to the value of their nearest remaining ancestor (the longest shorter key that stays in trie).
+// Longest prefix search (SearchPrefixIn) in result returns the same values as in original trie,
+// though found prefix can be shorter.
+//
+//	tr := {"/api/": v0, "/api/user": v0, "/api/user/list": v1}
+//
+//	tr.CoverAggregate(eq)
+//	-> {"/api/": v0, "/api/user/list": v1}
+func (t *Trie[T]) CoverAggregate(eq func(a, b T) bool) *Trie[T] {
+	return orEmpty(coverAggregate(t, nil, eq))
+}
+
+func coverAggregate[T any](t *Trie[T], inherited *T, eq func(a, b T) bool) *Trie[T] {
+	if t == nil {
+		return nil
+	}
+	var res = &Trie[T]{Prefix: t.Prefix}
+	if t.Value != nil && (inherited == nil || !eq(*inherited, *t.Value)) {
+		res.Value = t.Value
+		inherited = t.Value
+	}
+	if t.Children != nil {
+		for i, c := range t.Children {
+			res.setChild(i, coverAggregate(c, inherited, eq))
+		}
+	}
+	return res.normalized()
+}
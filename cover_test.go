@@ -0,0 +1,69 @@
+package trie
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTrie_Cover(t *testing.T) {
+	tr := BuildFromMap(map[string]string{
+		"/api/":            "api",
+		"/api/user":        "api",
+		"/api/user/list":   "list",
+		"/api/user/list/1": "list",
+		"/api/user/list/2": "api",
+		"/static/":         "static",
+		"/static/img":      "img",
+		"/health":          "health",
+	})
+
+	var shadowed []string
+	for key := range tr.Shadowed() {
+		shadowed = append(shadowed, string(key))
+	}
+	expected := []string{"/api/user", "/api/user/list", "/api/user/list/1", "/api/user/list/2", "/static/img"}
+	if !reflect.DeepEqual(shadowed, expected) {
+		t.Errorf("Shadowed: got %q expected %q", shadowed, expected)
+	}
+
+	cover := tr.MinimalCover()
+	if exp := BuildFromMap(map[string]string{"/api/": "api", "/static/": "static", "/health": "health"}); cover.String() != exp.String() {
+		t.Errorf("MinimalCover: not equal:\nexpected\n%s\ngot\n%s\n", exp, cover)
+	}
+
+	aggregated := tr.CoverAggregate(func(a, b string) bool { return a == b })
+	if exp := BuildFromMap(map[string]string{
+		"/api/":            "api",
+		"/api/user/list":   "list",
+		"/api/user/list/2": "api",
+		"/static/":         "static",
+		"/static/img":      "img",
+		"/health":          "health",
+	}); aggregated.String() != exp.String() {
+		t.Errorf("CoverAggregate: not equal:\nexpected\n%s\ngot\n%s\n", exp, aggregated)
+	}
+
+	for _, input := range []string{"/api/user/list/1/edit", "/api/user/list/2", "/api/user/5", "/static/img/logo.png", "/unknown"} {
+		expected, _, expectedOk := tr.SearchPrefixInString(input)
+		got, _, ok := aggregated.SearchPrefixInString(input)
+		if got != expected || ok != expectedOk {
+			t.Errorf("%q: got (%q, %t) expected (%q, %t)", input, got, ok, expected, expectedOk)
+		}
+		_, _, coverOk := cover.SearchPrefixInString(input)
+		if coverOk != expectedOk {
+			t.Errorf("%q: cover got %t expected %t", input, coverOk, expectedOk)
+		}
+	}
+}
+
+func ExampleTrie_Shadowed() {
+	blocked := BuildPrefixesOnly("ads.", "ads.example.", "tracker.", "tracker.example.")
+
+	for key := range blocked.Shadowed() {
+		fmt.Printf("%q is redundant\n", key)
+	}
+	// Output:
+	// "ads.example." is redundant
+	// "tracker.example." is redundant
+}
@@ -0,0 +1,269 @@
+package trie
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrModified is reported by Cursor when trie was modified after cursor was positioned.
+var ErrModified = errors.New("trie: modified after cursor was positioned")
+
+// Cursor walks over trie entries in lexicographic order in both directions.
+// It keeps path from the root to current entry, so Next and Prev don't walk from the root every time.
+//
+// Cursor watches modifications (Put, Delete, DeletePrefix, etc.) made through the Trie it was created from.
+// After any modification Next and Prev stop with ErrModified (see Err), until cursor is positioned again
+// with First, Last or Seek.
+// Only modifications made through the same *Trie value are detected. Tries sharing nodes with it
+// (e.g. results of SubTrie, or the original trie for a cursor created from SubTrie) have their own counters,
+// and their modifications are not noticed.
+//
+//	c := tr.Cursor()
+//	for ok := c.Seek(from); ok; ok = c.Next() {
+//		fmt.Println(c.Key(), c.Value())
+//	}
+//	if c.Err() != nil {
+//		// trie was modified during iteration
+//	}
+//
+// Not thread safe.
+type Cursor[T any] struct {
+	root *Trie[T]
+	// mods is a modification counter of root (nil if modifications are not watched)
+	mods *uint64
+	// seen is a value of mods at the moment cursor was positioned
+	seen uint64
+	err  error
+
+	// stack contains path from root to current entry. Cursor is valid only if stack is not empty.
+	stack []cursorFrame[T]
+	// key is a key of current entry. It's underlying array is reused between moves.
+	key []byte
+}
+
+type cursorFrame[T any] struct {
+	node   *Trie[T]
+	ind    int // index in parent's Children (-1 for root)
+	keyLen int // length of the key including node's prefix
+}
+
+// Cursor creates new unpositioned Cursor. Use First, Last or Seek to position it.
+// Creating cursors is safe concurrently with other reads of t.
+func (t *Trie[T]) Cursor() *Cursor[T] {
+	return newCursor(t, t.watch())
+}
+
+// newCursor creates cursor that watches mods (if it is not nil).
+// Cursors that are used only inside of read methods don't watch modifications, so they don't write anything to trie.
+func newCursor[T any](t *Trie[T], mods *uint64) *Cursor[T] {
+	return &Cursor[T]{
+		root:  t,
+		mods:  mods,
+		stack: make([]cursorFrame[T], 0, 16),
+		key:   make([]byte, 0, 64),
+	}
+}
+
+// First moves cursor to the smallest key. Returns false if trie is empty.
+func (c *Cursor[T]) First() bool {
+	c.start()
+	if c.root.Value != nil {
+		return true
+	}
+	return c.nextFrom(0)
+}
+
+// Last moves cursor to the greatest key. Returns false if trie is empty.
+func (c *Cursor[T]) Last() bool {
+	c.start()
+	return c.prevFrom(255)
+}
+
+// Seek moves cursor to the smallest key that is greater than or equal to key.
+// Returns false if there is no such key.
+func (c *Cursor[T]) Seek(key []byte) bool {
+	c.start()
+	var rest = key
+	for {
+		var node = c.stack[len(c.stack)-1].node
+
+		ind := 0
+		for ind < len(node.Prefix) && ind < len(rest) && node.Prefix[ind] == rest[ind] {
+			ind++
+		}
+
+		if ind < len(node.Prefix) {
+			if ind == len(rest) || node.Prefix[ind] > rest[ind] {
+				// all keys of current node are greater than searched one - take the first of them
+				if node.Value != nil {
+					return true
+				}
+				return c.nextFrom(0)
+			}
+			// all keys of current node are less than searched one - take the first one after them
+			return c.nextFrom(256)
+		}
+
+		if ind == len(rest) {
+			// exact match of prefix
+			if node.Value != nil {
+				return true
+			}
+			return c.nextFrom(0)
+		}
+
+		var next = rest[ind]
+		if node.Children == nil || node.Children[next] == nil {
+			return c.nextFrom(int(next) + 1)
+		}
+		c.push(node.Children[next], int(next))
+		rest = rest[ind:]
+	}
+}
+
+// Next moves cursor to the next key. Returns false if there are no more keys,
+// cursor is not positioned or trie was modified.
+func (c *Cursor[T]) Next() bool {
+	if !c.Valid() {
+		return false
+	}
+	return c.nextFrom(0)
+}
+
+// Prev moves cursor to the previous key. Returns false if there are no more keys,
+// cursor is not positioned or trie was modified.
+func (c *Cursor[T]) Prev() bool {
+	if !c.Valid() {
+		return false
+	}
+	var top = c.pop()
+	if len(c.stack) == 0 {
+		return false
+	}
+	return c.prevFrom(top.ind - 1)
+}
+
+// Valid reports whether cursor points to some entry.
+func (c *Cursor[T]) Valid() bool {
+	if len(c.stack) > 0 && c.mods != nil && atomic.LoadUint64(c.mods) != c.seen {
+		c.err = ErrModified
+		c.stack = c.stack[:0]
+	}
+	return len(c.stack) > 0
+}
+
+// Key returns key of current entry or nil if cursor is not valid.
+// Underlying array is reused - so you should copy key if you need it after cursor moves.
+func (c *Cursor[T]) Key() []byte {
+	if !c.Valid() {
+		return nil
+	}
+	return c.key[:c.stack[len(c.stack)-1].keyLen]
+}
+
+// Value returns value of current entry or zero value if cursor is not valid.
+func (c *Cursor[T]) Value() (value T) {
+	if !c.Valid() {
+		return value
+	}
+	return *c.stack[len(c.stack)-1].node.Value
+}
+
+// Err returns ErrModified if cursor was invalidated by modification of trie.
+func (c *Cursor[T]) Err() error {
+	c.Valid()
+	return c.err
+}
+
+// start resets cursor to the root and accepts current state of trie
+func (c *Cursor[T]) start() {
+	if c.mods != nil {
+		c.seen = atomic.LoadUint64(c.mods)
+	}
+	c.err = nil
+	c.stack = append(c.stack[:0], cursorFrame[T]{node: c.root, ind: -1, keyLen: len(c.root.Prefix)})
+	c.key = append(c.key[:0], c.root.Prefix...)
+}
+
+func (c *Cursor[T]) push(node *Trie[T], ind int) {
+	var keyLen = c.stack[len(c.stack)-1].keyLen
+	c.key = append(c.key[:keyLen], node.Prefix...)
+	c.stack = append(c.stack, cursorFrame[T]{node: node, ind: ind, keyLen: len(c.key)})
+}
+
+func (c *Cursor[T]) pop() cursorFrame[T] {
+	var top = c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+	return top
+}
+
+// nextFrom searches the first entry in children of current node (starting from index from)
+// and after current node, if there are no suitable children.
+func (c *Cursor[T]) nextFrom(from int) bool {
+	for {
+		var node = c.stack[len(c.stack)-1].node
+		if child := nextChild(node, from); child >= 0 {
+			c.push(node.Children[child], child)
+			if node.Children[child].Value != nil {
+				return true
+			}
+			from = 0
+			continue
+		}
+
+		// no more children - go up
+		var top = c.pop()
+		if len(c.stack) == 0 {
+			return false
+		}
+		from = top.ind + 1
+	}
+}
+
+// prevFrom searches the last entry in children of current node (with index up to to),
+// current node itself, and before current node, if there are no suitable children.
+func (c *Cursor[T]) prevFrom(to int) bool {
+	for {
+		var node = c.stack[len(c.stack)-1].node
+		if child := prevChild(node, to); child >= 0 {
+			c.push(node.Children[child], child)
+			to = 255
+			continue
+		}
+
+		// no more children - own value goes before them
+		if node.Value != nil {
+			return true
+		}
+
+		var top = c.pop()
+		if len(c.stack) == 0 {
+			return false
+		}
+		to = top.ind - 1
+	}
+}
+
+// nextChild returns index of the first child starting from index from (or -1)
+func nextChild[T any](t *Trie[T], from int) int {
+	if t.Children != nil {
+		for i := from; i < len(t.Children); i++ {
+			if t.Children[i] != nil {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// prevChild returns index of the last child with index up to to (or -1)
+func prevChild[T any](t *Trie[T], to int) int {
+	if t.Children != nil {
+		for i := to; i >= 0; i-- {
+			if t.Children[i] != nil {
+				return i
+			}
+		}
+	}
+	return -1
+}
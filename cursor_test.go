@@ -0,0 +1,146 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	var keys [][]byte
+	tr := &Trie[string]{}
+	for i := 0; i < 300; i++ {
+		var key = make([]byte, rand.Intn(5))
+		for j := range key {
+			key[j] = byte(rand.Intn(4)) * 0x40
+		}
+		if _, ok := tr.Get(key); !ok {
+			keys = append(keys, key)
+		}
+		tr.Put(key, string(key))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+
+	check := func(name string, c *Cursor[string], ok bool, expectedInd int) {
+		t.Helper()
+		if expectedInd < 0 || expectedInd >= len(keys) {
+			if ok || c.Valid() {
+				t.Fatalf("%s: got %v expected nothing", name, c.Key())
+			}
+			return
+		}
+		if !ok || !bytes.Equal(c.Key(), keys[expectedInd]) || c.Value() != string(keys[expectedInd]) {
+			t.Fatalf("%s: got %v (%t) expected %v", name, c.Key(), ok, keys[expectedInd])
+		}
+	}
+
+	c := tr.Cursor()
+	if c.Valid() || c.Next() || c.Prev() {
+		t.Errorf("cursor shouldn't be positioned after creation")
+	}
+
+	ok := c.First()
+	for i := 0; i <= len(keys); i++ {
+		check("Next", c, ok, i)
+		ok = c.Next()
+	}
+
+	ok = c.Last()
+	for i := len(keys) - 1; i >= -1; i-- {
+		check("Prev", c, ok, i)
+		ok = c.Prev()
+	}
+
+	for i := 0; i < 300; i++ {
+		var query = make([]byte, rand.Intn(6))
+		for j := range query {
+			query[j] = byte(rand.Intn(5)) * 0x30
+		}
+		ind, _ := slices.BinarySearchFunc(keys, query, bytes.Compare)
+
+		check(fmt.Sprintf("Seek(%v)", query), c, c.Seek(query), ind)
+		if c.Valid() {
+			check(fmt.Sprintf("Seek(%v).Next", query), c, c.Next(), ind+1)
+		}
+		if ind < len(keys) {
+			check(fmt.Sprintf("Seek(%v).Prev", query), c, c.Seek(query) && c.Prev(), ind-1)
+		}
+	}
+}
+
+func TestCursor_Modified(t *testing.T) {
+	tr := BuildPrefixesOnly("a", "b", "c")
+	c := tr.Cursor()
+	if !c.First() || c.Err() != nil {
+		t.Fatalf("cursor should be positioned")
+	}
+
+	tr.PutString("d", struct{}{})
+	if c.Valid() || c.Next() {
+		t.Errorf("cursor should be invalidated by Put")
+	}
+	if c.Err() != ErrModified {
+		t.Errorf("got error %v expected %v", c.Err(), ErrModified)
+	}
+
+	if !c.Seek([]byte("b")) || c.Err() != nil {
+		t.Fatalf("cursor should be positioned again")
+	}
+	tr.DeleteString("c")
+	if c.Prev() || c.Err() != ErrModified {
+		t.Errorf("cursor should be invalidated by Delete")
+	}
+}
+
+func TestCursor__Concurrent(t *testing.T) {
+	tr := BuildFromMap(map[string]int{"a": 1, "b": 2, "c": 3})
+
+	// cursors can be created on shared trie concurrently (checked with -race)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var count = 0
+			c := tr.Cursor()
+			for ok := c.First(); ok; ok = c.Next() {
+				count++
+			}
+			if count != 3 || c.Err() != nil {
+				t.Errorf("unexpected result (%d, %v)", count, c.Err())
+			}
+		}()
+	}
+	wg.Wait()
+
+	c := tr.Cursor()
+	c.First()
+	tr.PutString("d", 4)
+	if c.Next() || c.Err() != ErrModified {
+		t.Errorf("modification should be detected by all cursors")
+	}
+}
+
+func ExampleCursor() {
+	tr := BuildFromMap(map[string]int{
+		"v1.0": 10,
+		"v1.1": 11,
+		"v2.0": 20,
+		"v2.1": 21,
+	})
+
+	c := tr.Cursor()
+	for ok := c.Seek([]byte("v1.5")); ok; ok = c.Next() {
+		fmt.Println(string(c.Key()), c.Value())
+	}
+	if c.Last() && c.Prev() {
+		fmt.Println(string(c.Key()), c.Value())
+	}
+	// Output:
+	// v2.0 20
+	// v2.1 21
+	// v2.0 20
+}
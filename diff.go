@@ -0,0 +1,204 @@
+package trie
+
+import (
+	"bytes"
+	"iter"
+)
+
+// ChangeKind describes what happened with the key
+type ChangeKind int
+
+const (
+	Added   ChangeKind = 1 // key exists only in new trie
+	Removed ChangeKind = 2 // key exists only in old trie
+	Changed ChangeKind = 3 // key exists in both tries, but values are not equal
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	default:
+		return "unknown"
+	}
+}
+
+// Change describes difference between two tries for single key
+type Change[T any] struct {
+	Kind ChangeKind
+	Key  []byte
+	Old  T // previous value (for Removed and Changed)
+	New  T // new value (for Added and Changed)
+}
+
+// Diff returns iterator over changes that turn trie a into trie b, in lexicographic order of keys.
+// Values of keys present in both tries are compared with eq.
+//
+// Both tries are walked together only once. Subtrees that are shared by both tries
+// (e.g. after SubTrie or Merge) are skipped without visiting.
+//
+// Changes can be collected into Patch:
+//
+//	var patch trie.Patch[T] = slices.Collect(trie.Diff(a, b, eq))
+func Diff[T any](a, b *Trie[T], eq func(x, y T) bool) iter.Seq[Change[T]] {
+	return func(yield func(Change[T]) bool) {
+		diffAt(a, 0, b, 0, make([]byte, 0, 64), eq, yield)
+	}
+}
+
+// diffAt compares trie a starting from a.Prefix[aOff:] with trie b starting from b.Prefix[bOff:].
+// path contains all bytes before compared prefixes.
+func diffAt[T any](a *Trie[T], aOff int, b *Trie[T], bOff int, path []byte, eq func(x, y T) bool, yield func(Change[T]) bool) bool {
+	switch {
+	case a == b && aOff == bOff:
+		return true
+	case a == nil:
+		return emitAll(b, bOff, path, Added, yield)
+	case b == nil:
+		return emitAll(a, aOff, path, Removed, yield)
+	}
+
+	var aPrefix, bPrefix = a.Prefix[aOff:], b.Prefix[bOff:]
+	ind := 0
+	for ind < len(aPrefix) && ind < len(bPrefix) && aPrefix[ind] == bPrefix[ind] {
+		ind++
+	}
+	path = append(path, aPrefix[:ind]...)
+
+	switch {
+	case ind < len(aPrefix) && ind < len(bPrefix):
+		// prefixes diverged - all keys of a are removed and all keys of b are added
+		if aPrefix[ind] < bPrefix[ind] {
+			return emitAll(a, aOff+ind, path, Removed, yield) && emitAll(b, bOff+ind, path, Added, yield)
+		}
+		return emitAll(b, bOff+ind, path, Added, yield) && emitAll(a, aOff+ind, path, Removed, yield)
+	case ind < len(bPrefix):
+		// a is exhausted - it's value is removed, and only one of it's children continues with b
+		if a.Value != nil && !yield(Change[T]{Kind: Removed, Key: bytes.Clone(path), Old: *a.Value}) {
+			return false
+		}
+		var next = int(bPrefix[ind])
+		for i := 0; i < 256; i++ {
+			if i == next {
+				if !diffAt(a.child(i), 0, b, bOff+ind, path, eq, yield) {
+					return false
+				}
+			} else if c := a.child(i); c != nil && !emitAll(c, 0, path, Removed, yield) {
+				return false
+			}
+		}
+		return true
+	case ind < len(aPrefix):
+		// b is exhausted - it's value is added, and only one of it's children continues with a
+		if b.Value != nil && !yield(Change[T]{Kind: Added, Key: bytes.Clone(path), New: *b.Value}) {
+			return false
+		}
+		var next = int(aPrefix[ind])
+		for i := 0; i < 256; i++ {
+			if i == next {
+				if !diffAt(a, aOff+ind, b.child(i), 0, path, eq, yield) {
+					return false
+				}
+			} else if c := b.child(i); c != nil && !emitAll(c, 0, path, Added, yield) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// both prefixes are exhausted
+	switch {
+	case a.Value != nil && b.Value != nil:
+		if a.Value != b.Value && !eq(*a.Value, *b.Value) {
+			if !yield(Change[T]{Kind: Changed, Key: bytes.Clone(path), Old: *a.Value, New: *b.Value}) {
+				return false
+			}
+		}
+	case a.Value != nil:
+		if !yield(Change[T]{Kind: Removed, Key: bytes.Clone(path), Old: *a.Value}) {
+			return false
+		}
+	case b.Value != nil:
+		if !yield(Change[T]{Kind: Added, Key: bytes.Clone(path), New: *b.Value}) {
+			return false
+		}
+	}
+
+	if a.Children == b.Children {
+		return true
+	}
+	for i := 0; i < 256; i++ {
+		if !diffAt(a.child(i), 0, b.child(i), 0, path, eq, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// emitAll yields all values of t (starting from t.Prefix[off:]) as changes of specified kind
+func emitAll[T any](t *Trie[T], off int, path []byte, kind ChangeKind, yield func(Change[T]) bool) bool {
+	path = append(path, t.Prefix[off:]...)
+	if t.Value != nil {
+		var change = Change[T]{Kind: kind, Key: bytes.Clone(path)}
+		if kind == Removed {
+			change.Old = *t.Value
+		} else {
+			change.New = *t.Value
+		}
+		if !yield(change) {
+			return false
+		}
+	}
+	if t.Children != nil {
+		for _, c := range t.Children {
+			if c != nil && !emitAll(c, 0, path, kind, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// child returns child with specified index or nil
+func (t *Trie[T]) child(ind int) *Trie[T] {
+	if t.Children == nil {
+		return nil
+	}
+	return t.Children[ind]
+}
+
+// Patch is a list of changes (usually produced by Diff) that can be applied to trie
+type Patch[T any] []Change[T]
+
+// Apply puts added and changed values into t and deletes removed keys.
+func (p Patch[T]) Apply(t *Trie[T]) {
+	for _, change := range p {
+		switch change.Kind {
+		case Added, Changed:
+			t.Put(change.Key, change.New)
+		case Removed:
+			t.Delete(change.Key)
+		}
+	}
+}
+
+// Invert returns patch that reverts changes of p:
+// added keys become removed, removed become added, and old and new values of changed keys are swapped.
+func (p Patch[T]) Invert() Patch[T] {
+	var res = make(Patch[T], len(p))
+	for i, change := range p {
+		switch change.Kind {
+		case Added:
+			change.Kind = Removed
+		case Removed:
+			change.Kind = Added
+		}
+		change.Old, change.New = change.New, change.Old
+		res[len(p)-1-i] = change
+	}
+	return res
+}
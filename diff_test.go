@@ -0,0 +1,122 @@
+package trie
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	randomMap := func() map[string]int {
+		var res = make(map[string]int)
+		for i := 0; i < rand.Intn(100); i++ {
+			var b = make([]byte, rand.Intn(6))
+			for j := range b {
+				b[j] = "abc"[rand.Intn(3)]
+			}
+			res[string(b)] = rand.Intn(3)
+		}
+		return res
+	}
+	eq := func(x, y int) bool { return x == y }
+
+	for i := 0; i < 100; i++ {
+		a, b := randomMap(), randomMap()
+
+		var expected []string
+		for key, value := range a {
+			if newValue, ok := b[key]; !ok {
+				expected = append(expected, fmt.Sprintf("%s %q %d", Removed, key, value))
+			} else if newValue != value {
+				expected = append(expected, fmt.Sprintf("%s %q %d -> %d", Changed, key, value, newValue))
+			}
+		}
+		for key, value := range b {
+			if _, ok := a[key]; !ok {
+				expected = append(expected, fmt.Sprintf("%s %q %d", Added, key, value))
+			}
+		}
+		slices.Sort(expected)
+
+		aTrie, bTrie := BuildFromMap(a), BuildFromMap(b)
+		var patch Patch[int] = slices.Collect(Diff(aTrie, bTrie, eq))
+
+		var got []string
+		for i, change := range patch {
+			if i > 0 && string(patch[i-1].Key) >= string(change.Key) {
+				t.Errorf("wrong order: %q goes after %q", change.Key, patch[i-1].Key)
+			}
+			switch change.Kind {
+			case Added:
+				got = append(got, fmt.Sprintf("%s %q %d", change.Kind, change.Key, change.New))
+			case Removed:
+				got = append(got, fmt.Sprintf("%s %q %d", change.Kind, change.Key, change.Old))
+			case Changed:
+				got = append(got, fmt.Sprintf("%s %q %d -> %d", change.Kind, change.Key, change.Old, change.New))
+			}
+		}
+		slices.Sort(got)
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("got %q\nexpected %q", got, expected)
+		}
+
+		patched := aTrie.Clone(nil)
+		patch.Apply(patched)
+		if patched.String() != bTrie.String() {
+			t.Fatalf("patched trie differs:\nexpected\n%s\ngot\n%s\n", bTrie, patched)
+		}
+		patch.Invert().Apply(patched)
+		if patched.String() != aTrie.String() {
+			t.Fatalf("inverted patch doesn't restore trie:\nexpected\n%s\ngot\n%s\n", aTrie, patched)
+		}
+	}
+}
+
+func TestDiff__SharedSubtrees(t *testing.T) {
+	shared := BuildFromMap(map[string]int{"user": 1, "user/list": 2, "users": 3})
+	a := &Trie[int]{Prefix: []byte("/"), Children: &[256]*Trie[int]{
+		'a': {Prefix: []byte("api/"), Children: shared.Children},
+		'b': {Prefix: []byte("b"), Value: ptr(1)},
+	}}
+	b := &Trie[int]{Prefix: []byte("/"), Children: &[256]*Trie[int]{
+		'a': {Prefix: []byte("api/"), Children: shared.Children},
+		'b': {Prefix: []byte("b"), Value: ptr(2)},
+	}}
+
+	var compared = 0
+	var changes []Change[int]
+	for change := range Diff(a, b, func(x, y int) bool { compared++; return x == y }) {
+		changes = append(changes, change)
+	}
+
+	if compared != 1 {
+		t.Errorf("shared values shouldn't be compared: got %d comparisons", compared)
+	}
+	expected := []Change[int]{{Kind: Changed, Key: []byte("/b"), Old: 1, New: 2}}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("got %v expected %v", changes, expected)
+	}
+}
+
+func ExampleDiff() {
+	before := BuildFromMap(map[string]string{
+		"/api/user":  "v1",
+		"/api/group": "v1",
+		"/api/list":  "v1",
+	})
+	after := BuildFromMap(map[string]string{
+		"/api/user":     "v2",
+		"/api/group":    "v1",
+		"/api/articles": "v1",
+	})
+
+	for change := range Diff(before, after, func(x, y string) bool { return x == y }) {
+		fmt.Printf("%-7s %s %q -> %q\n", change.Kind, change.Key, change.Old, change.New)
+	}
+	// Output:
+	// added   /api/articles "" -> "v1"
+	// removed /api/list "v1" -> ""
+	// changed /api/user "v1" -> "v2"
+}
@@ -0,0 +1,119 @@
+package trie
+
+import (
+	"bytes"
+	"slices"
+)
+
+// FuzzyMatch is a single result of FuzzySearch
+type FuzzyMatch[T any] struct {
+	Key      []byte
+	Value    T
+	Distance int
+}
+
+// FuzzySearchString is a convenience method for FuzzySearch
+func (t *Trie[T]) FuzzySearchString(query string, maxEdits int, transpositions bool) []FuzzyMatch[T] {
+	return t.FuzzySearch([]byte(query), maxEdits, transpositions)
+}
+
+// FuzzySearch returns all keys within maxEdits edit distance from query (ordered by distance, then by key).
+// Distance is counted in bytes: every insertion, deletion or substitution of a byte is a single edit.
+// If transpositions is true - swap of two adjacent bytes is a single edit too
+// (optimal string alignment variant of Damerau-Levenshtein distance).
+//
+//	tr := {"commit": v0, "config": v1, "clone": v2}
+//
+//	tr.FuzzySearch("comit", 1, false)
+//	-> {"commit", v0, 1}
+//
+// Distances are calculated row by row while walking down the trie, so common parts of keys are processed once.
+// Subtrees are skipped as soon as all keys in them are known to be too far from query.
+func (t *Trie[T]) FuzzySearch(query []byte, maxEdits int, transpositions bool) []FuzzyMatch[T] {
+	if t == nil || maxEdits < 0 {
+		return nil
+	}
+
+	var s = &fuzzySearch[T]{
+		query:          query,
+		maxEdits:       maxEdits,
+		transpositions: transpositions,
+		rows:           [][]int{make([]int, len(query)+1)},
+		key:            make([]byte, 0, 64),
+	}
+	for j := range s.rows[0] {
+		s.rows[0][j] = j
+	}
+	s.walk(t)
+
+	slices.SortStableFunc(s.res, func(a, b FuzzyMatch[T]) int {
+		return a.Distance - b.Distance
+	})
+	return s.res
+}
+
+type fuzzySearch[T any] struct {
+	query          []byte
+	maxEdits       int
+	transpositions bool
+
+	// rows[i][j] is a distance between key[:i] and query[:j]. Rows are reused for different keys of the same length.
+	rows [][]int
+	// key is a path from the root to current position
+	key []byte
+	res []FuzzyMatch[T]
+}
+
+// walk checks all keys of t (results are collected in lexicographic order)
+func (s *fuzzySearch[T]) walk(t *Trie[T]) {
+	var keyLen = len(s.key)
+	for _, b := range t.Prefix {
+		if !s.step(b) {
+			s.key = s.key[:keyLen]
+			return
+		}
+	}
+
+	if t.Value != nil {
+		if distance := s.rows[len(s.key)][len(s.query)]; distance <= s.maxEdits {
+			s.res = append(s.res, FuzzyMatch[T]{Key: bytes.Clone(s.key), Value: *t.Value, Distance: distance})
+		}
+	}
+	if t.Children != nil {
+		for _, c := range t.Children {
+			if c != nil {
+				s.walk(c)
+			}
+		}
+	}
+	s.key = s.key[:keyLen]
+}
+
+// step appends b to key and calculates the next row.
+// Returns false if no key starting with current one can be within maxEdits from query.
+func (s *fuzzySearch[T]) step(b byte) bool {
+	s.key = append(s.key, b)
+	var i = len(s.key)
+	if len(s.rows) <= i {
+		s.rows = append(s.rows, make([]int, len(s.query)+1))
+	}
+
+	var prev, row = s.rows[i-1], s.rows[i]
+	row[0] = i
+	var best = row[0]
+	for j := 1; j < len(row); j++ {
+		var cost = 1
+		if s.query[j-1] == b {
+			cost = 0
+		}
+		row[j] = min(prev[j]+1, row[j-1]+1, prev[j-1]+cost)
+
+		if s.transpositions && i > 1 && j > 1 && b == s.query[j-2] && s.key[i-2] == s.query[j-1] {
+			row[j] = min(row[j], s.rows[i-2][j-2]+1)
+		}
+		best = min(best, row[j])
+	}
+
+	// distances can't decrease in the following rows (transposition costs at least as much as diagonal step)
+	return best <= s.maxEdits
+}
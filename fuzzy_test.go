@@ -0,0 +1,94 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// editDistance calculates Levenshtein (or optimal string alignment) distance with full matrix
+func editDistance(a, b []byte, transpositions bool) int {
+	var d = make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			var cost = 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if transpositions && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func TestTrie_FuzzySearch__Random(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		var tr = &Trie[int]{}
+		for j := 0; j < rand.Intn(30); j++ {
+			tr.Put(randomKey(5), j)
+		}
+		var query, maxEdits, transpositions = randomKey(5), rand.Intn(4) - 1, rand.Intn(2) == 0
+
+		var expected []FuzzyMatch[int]
+		if maxEdits >= 0 {
+			for key, value := range tr.All() {
+				if d := editDistance(key, query, transpositions); d <= maxEdits {
+					expected = append(expected, FuzzyMatch[int]{Key: bytes.Clone(key), Value: value, Distance: d})
+				}
+			}
+		}
+		slices.SortStableFunc(expected, func(a, b FuzzyMatch[int]) int {
+			return a.Distance - b.Distance
+		})
+
+		var res = tr.FuzzySearch(query, maxEdits, transpositions)
+		if fmt.Sprint(res) != fmt.Sprint(expected) && !(len(res) == 0 && len(expected) == 0) {
+			t.Fatalf("%v FuzzySearch(%q, %d, %v): got %v expected %v", tr, query, maxEdits, transpositions, res, expected)
+		}
+	}
+}
+
+func TestTrie_FuzzySearch(t *testing.T) {
+	tr := BuildFromMap(map[string]int{"commit": 1, "config": 2, "clone": 3})
+
+	if res := tr.FuzzySearchString("comit", 1, false); fmt.Sprint(res) != fmt.Sprint([]FuzzyMatch[int]{{[]byte("commit"), 1, 1}}) {
+		t.Errorf("unexpected result %v", res)
+	}
+	if res := tr.FuzzySearchString("colne", 1, false); len(res) != 0 {
+		t.Errorf("unexpected result %v", res)
+	}
+	if res := tr.FuzzySearchString("colne", 1, true); fmt.Sprint(res) != fmt.Sprint([]FuzzyMatch[int]{{[]byte("clone"), 3, 1}}) {
+		t.Errorf("unexpected result %v", res)
+	}
+	if res := tr.FuzzySearchString("clone", -1, false); res != nil {
+		t.Errorf("unexpected result %v", res)
+	}
+}
+
+func ExampleTrie_FuzzySearch() {
+	commands := BuildFromMap(map[string]string{
+		"checkout": "switch branches",
+		"cherry":   "find commits",
+		"commit":   "record changes",
+		"config":   "get and set options",
+		"clone":    "clone repository",
+	})
+
+	for _, m := range commands.FuzzySearchString("chekcout", 2, true) {
+		fmt.Printf("did you mean %q? (distance %d)\n", m.Key, m.Distance)
+	}
+	// Output:
+	// did you mean "checkout"? (distance 1)
+}
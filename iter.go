@@ -0,0 +1,200 @@
+package trie
+
+import (
+	"bytes"
+	"iter"
+)
+
+// All returns iterator over all keys and values stored in trie.
+//
+// Keys are yielded in lexicographic order (the same order bytes.Compare gives):
+//
+//	0x1, 0x1 0x1, 0x1 0x2, 0x1 0x3, 0x2, 0x2 0x1, 0x2 0x2, etc...
+//
+// Key's underlying array is reused between iterations - so you should copy it
+// (e.g. with bytes.Clone) if you need it after current iteration step.
+//
+// Not thread safe.
+func (t *Trie[T]) All() iter.Seq2[[]byte, T] {
+	return func(yield func([]byte, T) bool) {
+		if t != nil {
+			t.all(make([]byte, 0, 64), yield)
+		}
+	}
+}
+
+// Keys returns iterator over all keys stored in trie in lexicographic order.
+// As with All, key's underlying array is reused between iterations.
+func (t *Trie[T]) Keys() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		for key := range t.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns iterator over all values stored in trie in lexicographic order of their keys.
+func (t *Trie[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, value := range t.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// WithPrefix returns iterator over all keys and values, whose keys start with mask (mask itself included).
+// Yielded keys are complete (including mask). Order and key reuse are the same as for All.
+//
+//	tr := {"": v0, "/user/": v1, "/user/list": v2, "/group/": v3}.
+//
+//	tr.WithPrefix("/user")
+//	-> ("/user/", v1), ("/user/list", v2)
+func (t *Trie[T]) WithPrefix(mask []byte) iter.Seq2[[]byte, T] {
+	return func(yield func([]byte, T) bool) {
+		if t == nil {
+			return
+		}
+		if subTrie, ok := t.SubTrie(mask, true); ok {
+			subTrie.all(make([]byte, 0, 64), yield)
+		}
+	}
+}
+
+// Ancestors returns iterator over stored keys that are prefixes of key (key itself included)
+// with their values, from the shortest to the longest.
+// Yielded keys are subslices of key, so they are never copied.
+//
+//	tr := {"": v0, "/api/": v1, "/api/user": v2, "/api/user/list": v3}
+//
+//	tr.Ancestors("/api/user/5")
+//	-> ("", v0), ("/api/", v1), ("/api/user", v2)
+func (t *Trie[T]) Ancestors(key []byte) iter.Seq2[[]byte, T] {
+	return func(yield func([]byte, T) bool) {
+		t.SearchAllPrefixesIn(key, func(value T, prefixLen int) bool {
+			return yield(key[:prefixLen], value)
+		})
+	}
+}
+
+// Descendants returns iterator over stored keys that start with prefix and are longer than it
+// (unlike WithPrefix value for prefix itself is not yielded). Order and key reuse are the same as for All.
+//
+//	tr := {"": v0, "/api/": v1, "/api/user": v2, "/api/user/list": v3}
+//
+//	tr.Descendants("/api/user")
+//	-> ("/api/user/list", v3)
+func (t *Trie[T]) Descendants(prefix []byte) iter.Seq2[[]byte, T] {
+	return func(yield func([]byte, T) bool) {
+		if t == nil {
+			return
+		}
+		node, off, ok := t.locate(prefix)
+		if !ok {
+			return
+		}
+		var rest = &Trie[T]{Prefix: node.Prefix[off:], Children: node.Children}
+		if off < len(node.Prefix) {
+			// node's key is longer than prefix
+			rest.Value = node.Value
+		}
+		rest.all(append(make([]byte, 0, len(prefix)+64), prefix...), yield)
+	}
+}
+
+// Range returns iterator over keys and values with from <= key < to.
+// Nil from or to means that range is not bounded from that side.
+// If reverse is true - entries are yielded in descending order.
+// Subtrees that fall outside the range are skipped without visiting.
+// Key reuse is the same as for All.
+//
+//	tr := {"a": v0, "b": v1, "ba": v2, "c": v3}
+//
+//	tr.Range("b", "c", false)
+//	-> ("b", v1), ("ba", v2)
+//
+//	tr.Range("b", nil, true)
+//	-> ("c", v3), ("ba", v2), ("b", v1)
+func (t *Trie[T]) Range(from, to []byte, reverse bool) iter.Seq2[[]byte, T] {
+	return func(yield func([]byte, T) bool) {
+		if t != nil {
+			t.rangeAll(make([]byte, 0, 64), from, to, reverse, yield)
+		}
+	}
+}
+
+// rangeAll works like all, but skips children that are out of bounds.
+// from and to are reset to nil as soon as they can't affect any key of current trie.
+func (t *Trie[T]) rangeAll(prefix []byte, from, to []byte, reverse bool, yield func([]byte, T) bool) bool {
+	prefix = append(prefix, t.Prefix...)
+
+	if to != nil {
+		if bytes.Compare(prefix, to) >= 0 {
+			// every key of current trie starts with prefix and is not less than upper bound
+			return true
+		}
+		if !bytes.HasPrefix(to, prefix) {
+			// prefix < to and keys can't reach upper bound
+			to = nil
+		}
+	}
+	if from != nil {
+		if bytes.Compare(prefix, from) >= 0 {
+			// every key of current trie is not less than lower bound
+			from = nil
+		} else if !bytes.HasPrefix(from, prefix) {
+			// every key of current trie is less than lower bound
+			return true
+		}
+	}
+
+	// If bound is still set - prefix is a proper prefix of it. So it limits children indexes,
+	// and own value is out of range for lower bound.
+	var first, last = 0, 255
+	if from != nil {
+		first = int(from[len(prefix)])
+	}
+	if to != nil {
+		last = int(to[len(prefix)])
+	}
+	var withValue = t.Value != nil && from == nil
+
+	if !reverse && withValue && !yield(prefix, *t.Value) {
+		return false
+	}
+	if t.Children != nil {
+		for i := first; i <= last; i++ {
+			var ind = i
+			if reverse {
+				ind = first + last - i
+			}
+			if t.Children[ind] != nil && !t.Children[ind].rangeAll(prefix, from, to, reverse, yield) {
+				return false
+			}
+		}
+	}
+	if reverse && withValue && !yield(prefix, *t.Value) {
+		return false
+	}
+	return true
+}
+
+// all yields own value before values of children, and children in order of their indexes.
+// Since every key is greater than it's own prefixes - resulting order is lexicographic.
+func (t *Trie[T]) all(prefix []byte, yield func([]byte, T) bool) bool {
+	prefix = append(prefix, t.Prefix...)
+	if t.Value != nil && !yield(prefix, *t.Value) {
+		return false
+	}
+	if t.Children != nil {
+		for i := range t.Children {
+			if t.Children[i] != nil && !t.Children[i].all(prefix, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,273 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestTrie_All(t *testing.T) {
+	var keys [][]byte
+	tr := &Trie[int]{}
+	for i := 0; i < 500; i++ {
+		var key = make([]byte, rand.Intn(5))
+		for j := range key {
+			key[j] = byte(rand.Intn(4)) * 0x40
+		}
+		if _, ok := tr.Get(key); !ok {
+			keys = append(keys, key)
+		}
+		tr.Put(key, len(key))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+
+	var got [][]byte
+	for key, value := range tr.All() {
+		if value != len(key) {
+			t.Errorf("%v: got value %d expected %d", key, value, len(key))
+		}
+		got = append(got, bytes.Clone(key))
+	}
+	if !reflect.DeepEqual(got, keys) {
+		t.Errorf("wrong order:\ngot      %v\nexpected %v", got, keys)
+	}
+
+	got = got[:0]
+	for key := range tr.Keys() {
+		got = append(got, bytes.Clone(key))
+	}
+	if !reflect.DeepEqual(got, keys) {
+		t.Errorf("wrong keys order:\ngot      %v\nexpected %v", got, keys)
+	}
+
+	var count = 0
+	for range tr.Values() {
+		if count++; count == 10 {
+			break
+		}
+	}
+	if count != 10 {
+		t.Errorf("break doesn't stop iteration")
+	}
+
+	for range (*Trie[int])(nil).All() {
+		t.Errorf("nil trie shouldn't yield anything")
+	}
+}
+
+func TestTrie_WithPrefix(t *testing.T) {
+	tr := BuildFromMap(map[string]string{
+		"":                "root",
+		"/api/user":       "user",
+		"/api/user/list":  "users list",
+		"/api/group/":     "group",
+		"/api/group/list": "groups list",
+	})
+
+	var inputs = map[string][]string{
+		"/api/user":  {"/api/user", "/api/user/list"},
+		"/api/g":     {"/api/group/", "/api/group/list"},
+		"/api/test":  nil,
+		"/api/group": {"/api/group/", "/api/group/list"},
+		"": {
+			"", "/api/group/", "/api/group/list", "/api/user", "/api/user/list",
+		},
+	}
+
+	for mask, expected := range inputs {
+		var got []string
+		for key := range tr.WithPrefix([]byte(mask)) {
+			got = append(got, string(key))
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%q: got %q expected %q", mask, got, expected)
+		}
+	}
+}
+
+func ExampleTrie_All() {
+	tr := BuildFromMap(map[string]int{
+		"banana": 3,
+		"apple":  1,
+		"app":    0,
+		"cherry": 5,
+	})
+
+	for key, value := range tr.All() {
+		if key[0] == 'c' {
+			break
+		}
+		fmt.Println(string(key), value)
+	}
+	// Output:
+	// app 0
+	// apple 1
+	// banana 3
+}
+
+func TestTrie_Range(t *testing.T) {
+	var keys [][]byte
+	tr := &Trie[int]{}
+	for i := 0; i < 300; i++ {
+		var key = make([]byte, rand.Intn(5))
+		for j := range key {
+			key[j] = byte(rand.Intn(4)) * 0x40
+		}
+		if _, ok := tr.Get(key); !ok {
+			keys = append(keys, key)
+		}
+		tr.Put(key, len(key))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+
+	randomBound := func() []byte {
+		if rand.Intn(5) == 0 {
+			return nil
+		}
+		var b = make([]byte, rand.Intn(5))
+		for j := range b {
+			b[j] = byte(rand.Intn(5)) * 0x30
+		}
+		return b
+	}
+
+	for i := 0; i < 200; i++ {
+		from, to := randomBound(), randomBound()
+
+		var expected [][]byte
+		for _, key := range keys {
+			if (from == nil || bytes.Compare(key, from) >= 0) && (to == nil || bytes.Compare(key, to) < 0) {
+				expected = append(expected, key)
+			}
+		}
+
+		var got [][]byte
+		for key := range tr.Range(from, to, false) {
+			got = append(got, bytes.Clone(key))
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("[%v, %v): got %v expected %v", from, to, got, expected)
+		}
+
+		got = got[:0]
+		for key := range tr.Range(from, to, true) {
+			got = append(got, bytes.Clone(key))
+		}
+		slices.Reverse(expected)
+		if len(got) != len(expected) || (len(got) > 0 && !reflect.DeepEqual(got, expected)) {
+			t.Fatalf("reverse [%v, %v): got %v expected %v", from, to, got, expected)
+		}
+	}
+}
+
+func ExampleTrie_Range() {
+	tr := BuildFromMap(map[string]int{
+		"a":  0,
+		"b":  1,
+		"ba": 2,
+		"c":  3,
+	})
+
+	for key, value := range tr.Range([]byte("b"), []byte("c"), false) {
+		fmt.Println(string(key), value)
+	}
+	for key, value := range tr.Range([]byte("b"), nil, true) {
+		fmt.Println(string(key), value)
+	}
+	// Output:
+	// b 1
+	// ba 2
+	// c 3
+	// ba 2
+	// b 1
+}
+
+func TestTrie_Ancestors(t *testing.T) {
+	tr := BuildFromMap(map[string]string{
+		"":               "root",
+		"/api/":          "api",
+		"/api/user":      "user",
+		"/api/user/list": "list",
+		"/static/":       "static",
+	})
+
+	var inputs = map[string][]string{
+		"/api/user/5":    {"", "/api/", "/api/user"},
+		"/api/user/list": {"", "/api/", "/api/user", "/api/user/list"},
+		"/api":           {""},
+		"":               {""},
+	}
+
+	for key, expected := range inputs {
+		var got []string
+		for prefix, value := range tr.Ancestors([]byte(key)) {
+			got = append(got, string(prefix))
+			if v, _ := tr.Get(prefix); v != value {
+				t.Errorf("%q: got value %q for %q expected %q", key, value, prefix, v)
+			}
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%q: got %q expected %q", key, got, expected)
+		}
+
+		values := tr.AppendAll(nil, []byte(key))
+		if !reflect.DeepEqual(values, tr.GetAll([]byte(key))) {
+			t.Errorf("%q: AppendAll %q differs from GetAll %q", key, values, tr.GetAll([]byte(key)))
+		}
+	}
+
+	var buf = make([]string, 0, 10)
+	if allocs := testing.AllocsPerRun(100, func() {
+		buf = tr.AppendAll(buf[:0], []byte("/api/user/list"))
+	}); allocs != 0 {
+		t.Errorf("AppendAll makes %v allocations", allocs)
+	}
+}
+
+func TestTrie_Descendants(t *testing.T) {
+	tr := BuildFromMap(map[string]string{
+		"":               "root",
+		"/api/":          "api",
+		"/api/user":      "user",
+		"/api/user/list": "list",
+		"/api/users":     "users",
+	})
+
+	var inputs = map[string][]string{
+		"/api/user":  {"/api/user/list", "/api/users"},
+		"/api/users": nil,
+		"/api/u":     {"/api/user", "/api/user/list", "/api/users"},
+		"/static":    nil,
+		"":           {"/api/", "/api/user", "/api/user/list", "/api/users"},
+	}
+
+	for prefix, expected := range inputs {
+		var got []string
+		for key := range tr.Descendants([]byte(prefix)) {
+			got = append(got, string(key))
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%q: got %q expected %q", prefix, got, expected)
+		}
+	}
+}
+
+func ExampleTrie_Ancestors() {
+	middlewares := BuildFromMap(map[string]string{
+		"/":           "logging",
+		"/admin/":     "auth",
+		"/admin/api/": "json",
+	})
+
+	var path = []byte("/admin/api/users")
+	for prefix, middleware := range middlewares.Ancestors(path) {
+		fmt.Printf("%-7s (rest of path %s)\n", middleware, path[len(prefix):])
+	}
+	// Output:
+	// logging (rest of path admin/api/users)
+	// auth    (rest of path api/users)
+	// json    (rest of path users)
+}
@@ -0,0 +1,110 @@
+package trie
+
+import "bytes"
+
+// Entry is a key with associated value
+type Entry[T any] struct {
+	Key   []byte
+	Value T
+}
+
+// ListResult is a page of entries returned by List
+type ListResult[T any] struct {
+	// Entries contains keys (with values) that don't contain delimiter after prefix
+	Entries []Entry[T]
+	// CommonPrefixes contains distinct parts of keys from the beginning up to the first delimiter after prefix (delimiter included)
+	CommonPrefixes [][]byte
+	// IsTruncated reports whether limit was reached, but there are more entries or common prefixes
+	IsTruncated bool
+	// NextStartAfter should be passed as startAfter to get the next page (nil if result is not truncated)
+	NextStartAfter []byte
+}
+
+// List works like S3 ListObjectsV2. It returns entries whose keys start with prefix and are greater than startAfter
+// in lexicographic order.
+//
+// If delimiter is not empty - keys containing delimiter after prefix are rolled up into common prefixes
+// (part of the key up to the first such delimiter) and every common prefix is returned only once.
+// All keys under already returned common prefix are skipped without visiting.
+// Common prefix is also treated as a key for startAfter, so NextStartAfter can point to it.
+//
+// limit restricts total amount of entries and common prefixes (zero or negative means no limit).
+// Nil startAfter means that listing starts from the beginning.
+// List doesn't write anything to trie, so it can be called concurrently with other reads.
+//
+//	tr := {"a/1": v0, "a/b/2": v1, "a/b/3": v2, "a/c/4": v3, "b/5": v4}
+//
+//	tr.List("a/", "/", nil, 2)
+//	-> Entries: [("a/1", v0)], CommonPrefixes: ["a/b/"], IsTruncated: true, NextStartAfter: "a/b/"
+//
+//	tr.List("a/", "/", "a/b/", 2)
+//	-> CommonPrefixes: ["a/c/"], IsTruncated: false
+func (t *Trie[T]) List(prefix, delimiter, startAfter []byte, limit int) (res ListResult[T]) {
+	var c = newCursor(t, nil)
+	var ok bool
+	if bytes.Compare(startAfter, prefix) > 0 {
+		ok = c.Seek(startAfter)
+	} else {
+		ok = c.Seek(prefix)
+	}
+
+	var count = 0
+	var last []byte
+	for ok {
+		var key = c.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+
+		var item, isCommonPrefix = key, false
+		if len(delimiter) > 0 {
+			if ind := bytes.Index(key[len(prefix):], delimiter); ind >= 0 {
+				item, isCommonPrefix = key[:len(prefix)+ind+len(delimiter)], true
+			}
+		}
+
+		if startAfter != nil && bytes.Compare(item, startAfter) <= 0 {
+			// already listed on previous pages
+			if isCommonPrefix {
+				ok = seekAfterPrefix(c, item)
+			} else {
+				ok = c.Next()
+			}
+			continue
+		}
+
+		if limit > 0 && count == limit {
+			res.IsTruncated = true
+			res.NextStartAfter = last
+			break
+		}
+		count++
+
+		last = bytes.Clone(item)
+		if isCommonPrefix {
+			res.CommonPrefixes = append(res.CommonPrefixes, last)
+			ok = seekAfterPrefix(c, item)
+		} else {
+			res.Entries = append(res.Entries, Entry[T]{Key: last, Value: c.Value()})
+			ok = c.Next()
+		}
+	}
+
+	return res
+}
+
+// seekAfterPrefix moves cursor to the first key that doesn't start with prefix and is greater than prefix.
+func seekAfterPrefix[T any](c *Cursor[T], prefix []byte) bool {
+	// the smallest key greater than all keys with such prefix: increment last byte that is not 0xFF
+	var end = bytes.Clone(prefix)
+	for len(end) > 0 && end[len(end)-1] == 0xFF {
+		end = end[:len(end)-1]
+	}
+	if len(end) == 0 {
+		// prefix consists of 0xFF only - nothing can be greater
+		c.stack = c.stack[:0]
+		return false
+	}
+	end[len(end)-1]++
+	return c.Seek(end)
+}
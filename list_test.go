@@ -0,0 +1,127 @@
+package trie
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestTrie_List(t *testing.T) {
+	tr := BuildFromMap(map[string]int{
+		"readme.txt":             0,
+		"photos/":                1,
+		"photos/2021/a.jpg":      2,
+		"photos/2021/b.jpg":      3,
+		"photos/2022/c.jpg":      4,
+		"photos/2022/raw/d.raw":  5,
+		"photos/cover.jpg":       6,
+		"photos/\xFF\xFF/e.jpg":  7,
+		"photos/\xFF\xFF/f.jpg":  8,
+		"photosets/x":            9,
+		"videos/2021/movie.mp4":  10,
+		"videos/2021/movie2.mp4": 11,
+	})
+
+	type page struct {
+		entries  []string
+		prefixes []string
+	}
+
+	tests := []struct {
+		prefix    string
+		delimiter string
+		limit     int
+		pages     []page
+	}{
+		{"", "/", 0, []page{{[]string{"readme.txt"}, []string{"photos/", "photosets/", "videos/"}}}},
+		{"photos/", "/", 0, []page{{[]string{"photos/", "photos/cover.jpg"}, []string{"photos/2021/", "photos/2022/", "photos/\xFF\xFF/"}}}},
+		{"photos/", "/", 2, []page{
+			{[]string{"photos/"}, []string{"photos/2021/"}},
+			{[]string{"photos/cover.jpg"}, []string{"photos/2022/"}},
+			{nil, []string{"photos/\xFF\xFF/"}},
+		}},
+		{"photos/2022/", "", 1, []page{
+			{[]string{"photos/2022/c.jpg"}, nil},
+			{[]string{"photos/2022/raw/d.raw"}, nil},
+		}},
+		{"videos/2021/movie", "", 0, []page{{[]string{"videos/2021/movie.mp4", "videos/2021/movie2.mp4"}, nil}}},
+		{"music/", "/", 0, []page{{nil, nil}}},
+	}
+
+	for _, tt := range tests {
+		var startAfter []byte
+		for i, expected := range tt.pages {
+			res := tr.List([]byte(tt.prefix), []byte(tt.delimiter), startAfter, tt.limit)
+
+			var got page
+			for _, e := range res.Entries {
+				got.entries = append(got.entries, string(e.Key))
+			}
+			for _, p := range res.CommonPrefixes {
+				got.prefixes = append(got.prefixes, string(p))
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("%q %q page %d: got %q expected %q", tt.prefix, tt.delimiter, i, got, expected)
+			}
+
+			if res.IsTruncated != (i < len(tt.pages)-1) {
+				t.Errorf("%q %q page %d: wrong truncation flag %t", tt.prefix, tt.delimiter, i, res.IsTruncated)
+			}
+			startAfter = res.NextStartAfter
+		}
+	}
+}
+
+func TestTrie_List__Concurrent(t *testing.T) {
+	tr := BuildFromMap(map[string]int{"a/1": 1, "a/2": 2, "b/3": 3})
+
+	// List must not write to trie (checked with -race)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if res := tr.List(nil, []byte("/"), nil, 0); len(res.CommonPrefixes) != 2 {
+				t.Errorf("unexpected result %v", res)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tr.mods != nil {
+		t.Errorf("List should not attach modification counter")
+	}
+}
+
+func ExampleTrie_List() {
+	tr := BuildFromMap(map[string]int{
+		"docs/a.txt":        1,
+		"docs/b.txt":        2,
+		"docs/drafts/c.txt": 3,
+		"docs/old/d.txt":    4,
+		"readme.txt":        5,
+	})
+
+	var startAfter []byte
+	for {
+		res := tr.List([]byte("docs/"), []byte("/"), startAfter, 3)
+		for _, e := range res.Entries {
+			fmt.Printf("%s = %d\n", e.Key, e.Value)
+		}
+		for _, p := range res.CommonPrefixes {
+			fmt.Printf("%s\n", p)
+		}
+		if !res.IsTruncated {
+			break
+		}
+		fmt.Println("---")
+		startAfter = res.NextStartAfter
+	}
+	// Output:
+	// docs/a.txt = 1
+	// docs/b.txt = 2
+	// docs/drafts/
+	// ---
+	// docs/old/
+}
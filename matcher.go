@@ -0,0 +1,245 @@
+package trie
+
+import (
+	"bytes"
+	"iter"
+	"slices"
+)
+
+// MatchKind defines which occurrences are reported by Matcher
+type MatchKind int
+
+const (
+	// MatchOverlapping reports every occurrence of every key, including overlapping ones.
+	// Matches are reported in order of their ends, and matches with the same end - from the longest to the shortest.
+	MatchOverlapping MatchKind = 0
+	// MatchLeftmostLongest reports non-overlapping matches: at every step the match that starts first is taken,
+	// and of matches starting at the same position - the longest one.
+	// The same matches are found by calling SearchPrefixIn at every position and skipping found prefix.
+	MatchLeftmostLongest MatchKind = 1
+	// MatchLeftmostFirst reports non-overlapping matches: at every step the match that starts first is taken,
+	// and of matches starting at the same position - the first one in lexicographic order (which is the shortest).
+	MatchLeftmostFirst MatchKind = 2
+)
+
+// Match is a single occurrence of stored key in text: text[Start:End] is the key
+type Match[T any] struct {
+	Start int
+	End   int
+	Value T
+}
+
+// Matcher searches all stored keys in arbitrary text in a single pass (Aho-Corasick automaton).
+// It is compiled from Trie by expanding prefixes into separate states and adding failure links,
+// so time of search doesn't depend on amount of keys.
+//
+// Matcher is a snapshot: later changes of trie are not visible to it.
+// Empty key is ignored. Matcher is safe for concurrent use.
+type Matcher[T any] struct {
+	// forward automaton is built from keys
+	forward automaton
+	// backward automaton is built from reversed keys - it finds keys starting at every position of text (see FindAll)
+	backward automaton
+	values   []T
+}
+
+type automaton struct {
+	states []matcherState
+}
+
+type matcherState struct {
+	labels   []byte  // bytes of outgoing transitions (sorted)
+	next     []int32 // target states of outgoing transitions
+	fail     int32   // state for the longest proper suffix, that is a prefix of some key
+	output   int32   // the nearest state in the failure chain (including current one) that ends some key, or -1
+	shortest int32   // the last state in the failure chain that ends some key (the shortest key), or -1
+	value    int32   // index of value in values if current state ends some key, or -1
+	depth    int32   // length of the string that leads to current state
+}
+
+// NewMatcher compiles Matcher from trie.
+func NewMatcher[T any](t *Trie[T]) *Matcher[T] {
+	var m = &Matcher[T]{
+		forward:  newAutomaton(),
+		backward: newAutomaton(),
+	}
+	if t != nil {
+		m.add(t, 0, make([]byte, 0, 64))
+	}
+	m.forward.link()
+	m.backward.link()
+	return m
+}
+
+// add creates states for t's prefix (starting from state from) and for all it's children.
+// path contains all bytes before t.Prefix.
+func (m *Matcher[T]) add(t *Trie[T], from int32, path []byte) {
+	var cur = from
+	for _, b := range t.Prefix {
+		// trie has no common prefixes in different nodes, so every byte leads to new state
+		cur = m.forward.addState(cur, b)
+	}
+	path = append(path, t.Prefix...)
+
+	if t.Value != nil && cur != 0 {
+		var value = int32(len(m.values))
+		m.values = append(m.values, *t.Value)
+		m.forward.states[cur].value = value
+
+		var rev int32 = 0
+		for i := len(path) - 1; i >= 0; i-- {
+			if next := m.backward.goTo(rev, path[i]); next >= 0 {
+				rev = next
+			} else {
+				rev = m.backward.addState(rev, path[i])
+			}
+		}
+		m.backward.states[rev].value = value
+	}
+	if t.Children != nil {
+		for _, c := range t.Children {
+			if c != nil {
+				m.add(c, cur, path)
+			}
+		}
+	}
+}
+
+func newAutomaton() automaton {
+	return automaton{states: []matcherState{{fail: 0, output: -1, shortest: -1, value: -1}}}
+}
+
+// addState creates new state with transition from state from by byte b
+func (a *automaton) addState(from int32, b byte) int32 {
+	var next = int32(len(a.states))
+	a.states = append(a.states, matcherState{output: -1, shortest: -1, value: -1, depth: a.states[from].depth + 1})
+	var s = &a.states[from]
+	var i = len(s.labels)
+	for i > 0 && s.labels[i-1] > b {
+		i--
+	}
+	s.labels = slices.Insert(s.labels, i, b)
+	s.next = slices.Insert(s.next, i, next)
+	return next
+}
+
+// link calculates failure and output links in order of depth
+func (a *automaton) link() {
+	var queue = make([]int32, 0, len(a.states))
+	queue = append(queue, 0)
+	for len(queue) > 0 {
+		var cur = queue[0]
+		queue = queue[1:]
+
+		for i, b := range a.states[cur].labels {
+			var next = a.states[cur].next[i]
+			queue = append(queue, next)
+
+			var fail int32 = 0
+			if cur != 0 {
+				fail = a.step(a.states[cur].fail, b)
+			}
+			var state = &a.states[next]
+			state.fail = fail
+			if state.value >= 0 {
+				state.output = next
+			} else {
+				state.output = a.states[fail].output
+			}
+			if state.shortest = a.states[fail].shortest; state.shortest < 0 && state.value >= 0 {
+				state.shortest = next
+			}
+		}
+	}
+}
+
+// goTo returns target of transition from state by byte b or -1
+func (a *automaton) goTo(state int32, b byte) int32 {
+	if i := bytes.IndexByte(a.states[state].labels, b); i >= 0 {
+		return a.states[state].next[i]
+	}
+	return -1
+}
+
+// step returns the next state after reading byte b (following failure links if needed)
+func (a *automaton) step(state int32, b byte) int32 {
+	for {
+		if next := a.goTo(state, b); next >= 0 {
+			return next
+		}
+		if state == 0 {
+			return 0
+		}
+		state = a.states[state].fail
+	}
+}
+
+// FindAllString is a convenience method for FindAll
+func (m *Matcher[T]) FindAllString(text string, kind MatchKind) iter.Seq[Match[T]] {
+	return m.FindAll([]byte(text), kind)
+}
+
+// FindAll returns iterator over occurrences of stored keys in text (see MatchKind).
+// All kinds of search take linear time of text length (plus amount of matches).
+//
+// Leftmost searches never read text again after reported match. Instead, they first scan the whole text backwards
+// with reversed keys to find the longest (or the shortest) key starting at every position, and then take found keys
+// from left to right. So they need 4 bytes of memory per byte of text, and the first match is reported only after
+// the whole text is scanned.
+func (m *Matcher[T]) FindAll(text []byte, kind MatchKind) iter.Seq[Match[T]] {
+	return func(yield func(Match[T]) bool) {
+		if len(m.values) == 0 {
+			return
+		}
+		if kind == MatchOverlapping {
+			m.findOverlapping(text, yield)
+		} else {
+			m.findLeftmost(text, kind == MatchLeftmostLongest, yield)
+		}
+	}
+}
+
+func (m *Matcher[T]) findOverlapping(text []byte, yield func(Match[T]) bool) {
+	var a = &m.forward
+	var state int32 = 0
+	for pos := 0; pos < len(text); pos++ {
+		state = a.step(state, text[pos])
+		for out := a.states[state].output; out >= 0; out = a.states[a.states[out].fail].output {
+			var s = &a.states[out]
+			if !yield(Match[T]{Start: pos + 1 - int(s.depth), End: pos + 1, Value: m.values[s.value]}) {
+				return
+			}
+		}
+	}
+}
+
+func (m *Matcher[T]) findLeftmost(text []byte, longest bool, yield func(Match[T]) bool) {
+	var a = &m.backward
+
+	// starts contains state of the key starting at every position of text (or -1).
+	// Reading text backwards, current state corresponds to the longest text[pos:pos+depth] that is a suffix
+	// of some key, so all keys in it's output chain start exactly at pos.
+	var starts = make([]int32, len(text))
+	var state int32 = 0
+	for pos := len(text) - 1; pos >= 0; pos-- {
+		state = a.step(state, text[pos])
+		if longest {
+			starts[pos] = a.states[state].output
+		} else {
+			starts[pos] = a.states[state].shortest
+		}
+	}
+
+	for pos := 0; pos < len(text); {
+		if starts[pos] < 0 {
+			pos++
+			continue
+		}
+		var s = &a.states[starts[pos]]
+		var end = pos + int(s.depth)
+		if !yield(Match[T]{Start: pos, End: end, Value: m.values[s.value]}) {
+			return
+		}
+		pos = end
+	}
+}
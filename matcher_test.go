@@ -0,0 +1,143 @@
+package trie
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatcher(t *testing.T) {
+	randomString := func(maxLen int) string {
+		var b = make([]byte, 1+rand.Intn(maxLen))
+		for i := range b {
+			b[i] = "abc"[rand.Intn(3)]
+		}
+		return string(b)
+	}
+
+	for i := 0; i < 200; i++ {
+		tr := &Trie[string]{}
+		for j := 0; j < 1+rand.Intn(10); j++ {
+			key := randomString(5)
+			tr.PutString(key, key)
+		}
+		text := []byte(randomString(50))
+		m := NewMatcher(tr)
+
+		// overlapping: all substrings that are keys
+		var expected []Match[string]
+		for end := 1; end <= len(text); end++ {
+			for start := 0; start < end; start++ {
+				if v, ok := tr.Get(text[start:end]); ok {
+					expected = append(expected, Match[string]{start, end, v})
+				}
+			}
+		}
+		if got := slices.Collect(m.FindAll(text, MatchOverlapping)); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("overlapping %q in %q:\ngot      %v\nexpected %v", slices.Collect(tr.Values()), text, got, expected)
+		}
+
+		// leftmost: prefix search at every position
+		for _, kind := range []MatchKind{MatchLeftmostLongest, MatchLeftmostFirst} {
+			expected = expected[:0]
+			for pos := 0; pos < len(text); {
+				var v string
+				var l int
+				var ok bool
+				if kind == MatchLeftmostLongest {
+					v, l, ok = tr.SearchPrefixIn(text[pos:])
+				} else {
+					v, l, ok = tr.SearchShortestPrefixIn(text[pos:])
+				}
+				if ok {
+					expected = append(expected, Match[string]{pos, pos + l, v})
+					pos += l
+				} else {
+					pos++
+				}
+			}
+			got := slices.Collect(m.FindAll(text, kind))
+			if len(got) != len(expected) || len(got) > 0 && !reflect.DeepEqual(got, expected) {
+				t.Fatalf("kind %d %q in %q:\ngot      %v\nexpected %v", kind, slices.Collect(tr.Values()), text, got, expected)
+			}
+		}
+	}
+}
+
+func TestMatcher__Stop(t *testing.T) {
+	m := NewMatcher(BuildPrefixesOnly("a", "aa", ""))
+	for _, kind := range []MatchKind{MatchOverlapping, MatchLeftmostLongest, MatchLeftmostFirst} {
+		var count = 0
+		for range m.FindAllString("aaaaaa", kind) {
+			if count++; count == 2 {
+				break
+			}
+		}
+		if count != 2 {
+			t.Errorf("kind %d: got %d matches", kind, count)
+		}
+	}
+	for range NewMatcher((*Trie[int])(nil)).FindAllString("aaa", MatchOverlapping) {
+		t.Errorf("empty matcher shouldn't find anything")
+	}
+}
+
+func TestMatcher__Linear(t *testing.T) {
+	// long key almost matches at every position - leftmost searches should not read it again after every match
+	tr := BuildPrefixesOnly("a", strings.Repeat("a", 2000)+"b")
+	m := NewMatcher(tr)
+	text := []byte(strings.Repeat("a", 200000))
+
+	measure := func(kind MatchKind) time.Duration {
+		var start = time.Now()
+		var count = 0
+		for match := range m.FindAll(text, kind) {
+			if match.End-match.Start != 1 {
+				t.Fatalf("unexpected match %v", match)
+			}
+			count++
+		}
+		if count != len(text) {
+			t.Fatalf("kind %d: got %d matches expected %d", kind, count, len(text))
+		}
+		return time.Since(start)
+	}
+
+	var overlapping = measure(MatchOverlapping)
+	for _, kind := range []MatchKind{MatchLeftmostLongest, MatchLeftmostFirst} {
+		// quadratic search takes about thousand times longer
+		if d := measure(kind); d > 20*overlapping+100*time.Millisecond {
+			t.Errorf("kind %d: search took %v, but overlapping one took %v", kind, d, overlapping)
+		}
+	}
+}
+
+func ExampleMatcher() {
+	emojis := BuildFromMap(map[string]string{
+		"👨":   "man",
+		"👨‍🔧": "mechanic",
+		"🔧":   "wrench",
+	})
+	m := NewMatcher(emojis)
+
+	var text = "Call 👨‍🔧 with 🔧"
+	for match := range m.FindAllString(text, MatchLeftmostLongest) {
+		fmt.Printf("%d-%d %s\n", match.Start, match.End, match.Value)
+	}
+	fmt.Println("---")
+	for match := range m.FindAllString(text, MatchOverlapping) {
+		fmt.Printf("%d-%d %s\n", match.Start, match.End, match.Value)
+	}
+	// Output:
+	// 5-16 mechanic
+	// 22-26 wrench
+	// ---
+	// 5-9 man
+	// 5-16 mechanic
+	// 12-16 wrench
+	// 22-26 wrench
+}
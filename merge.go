@@ -0,0 +1,106 @@
+package trie
+
+// Merge adds all entries of src into dst.
+// For keys present in both tries resolve is called with the key and both values (from dst and from src),
+// and it's result is stored in dst. If resolve is nil - value from src wins.
+// Key passed to resolve is reused - copy it if you need it after resolve returns.
+//
+// Both tries are walked together: subtrees of src that don't exist in dst are moved into dst as is,
+// without visiting their keys. So src is consumed by Merge and must not be used afterwards.
+// If you need to keep src - merge it's Clone.
+func Merge[T any](dst, src *Trie[T], resolve func(key []byte, a, b T) T) {
+	if src == nil || src.Value == nil && src.Children == nil {
+		return
+	}
+
+	if dst.Value == nil && dst.Children == nil {
+		// empty dst just takes everything
+		dst.Prefix, dst.Value, dst.Children = src.Prefix, src.Value, src.Children
+	} else {
+		merge(dst, src, make([]byte, 0, 64), resolve)
+		dst.compact()
+	}
+	dst.modified()
+}
+
+// merge merges s into d. Both tries start at the same position (their prefixes start with the same byte).
+// path contains all bytes before d.Prefix.
+func merge[T any](d, s *Trie[T], path []byte, resolve func(key []byte, a, b T) T) {
+	ind := 0
+	for ind < len(d.Prefix) && ind < len(s.Prefix) && d.Prefix[ind] == s.Prefix[ind] {
+		ind++
+	}
+
+	if ind < len(d.Prefix) {
+		// s.Prefix is shorter or they diverged - d should end at common part
+		d.split(ind)
+	}
+	path = append(path, d.Prefix...)
+
+	if ind < len(s.Prefix) {
+		// s continues below d
+		s.Prefix = s.Prefix[ind:]
+		mergeChild(d, s, path, resolve)
+		return
+	}
+
+	// d and s are at the same position
+	if s.Value != nil {
+		if d.Value != nil && resolve != nil {
+			var value = resolve(path, *d.Value, *s.Value)
+			d.Value = &value
+		} else {
+			d.Value = s.Value
+		}
+	}
+	if s.Children != nil {
+		for _, c := range s.Children {
+			if c != nil {
+				mergeChild(d, c, path, resolve)
+			}
+		}
+	}
+}
+
+// mergeChild moves c into proper child of d or merges them, if d already has such child
+func mergeChild[T any](d, c *Trie[T], path []byte, resolve func(key []byte, a, b T) T) {
+	var ind = c.Prefix[0]
+	if d.Children == nil {
+		d.Children = &[256]*Trie[T]{}
+	}
+	if d.Children[ind] == nil {
+		d.Children[ind] = c
+	} else {
+		merge(d.Children[ind], c, path, resolve)
+	}
+}
+
+// Graft attaches all entries of sub under prefix: every key k of sub is stored as prefix+k.
+// It is the inverse of SubTrie(prefix, false).
+//
+//	tr := {"/": v0}
+//	users := {"": v1, "/list": v2}
+//
+//	tr.Graft("/api/users", users, nil)
+//	-> {"/": v0, "/api/users": v1, "/api/users/list": v2}
+//
+// Existing nodes are split where needed, but keys of sub are not inserted one by one.
+// For keys present in both tries onConflict is called with the key and both values, and it's result is stored.
+// If onConflict is nil - value from sub wins. Key passed to onConflict is reused.
+//
+// As with Merge, nodes of sub are moved into t, so sub must not be used afterwards.
+func (t *Trie[T]) Graft(prefix []byte, sub *Trie[T], onConflict func(key []byte, existing, grafted T) T) {
+	if sub == nil || sub.Value == nil && sub.Children == nil {
+		return
+	}
+
+	var src = &Trie[T]{
+		Prefix:   make([]byte, len(prefix)+len(sub.Prefix)),
+		Value:    sub.Value,
+		Children: sub.Children,
+	}
+	copy(src.Prefix, prefix)
+	copy(src.Prefix[len(prefix):], sub.Prefix)
+
+	Merge(t, src, onConflict)
+}
@@ -0,0 +1,119 @@
+package trie
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	randomMap := func() map[string]int {
+		var res = make(map[string]int)
+		for i := 0; i < rand.Intn(100); i++ {
+			var b = make([]byte, rand.Intn(6))
+			for j := range b {
+				b[j] = "abc"[rand.Intn(3)]
+			}
+			res[string(b)] = rand.Intn(100)
+		}
+		return res
+	}
+
+	for i := 0; i < 100; i++ {
+		a, b := randomMap(), randomMap()
+
+		var expected = make(map[string]int)
+		for key, value := range a {
+			expected[key] = value
+		}
+		var conflicts = 0
+		for key, value := range b {
+			if _, ok := expected[key]; ok {
+				conflicts++
+				expected[key] -= value
+			} else {
+				expected[key] = value
+			}
+		}
+
+		dst, src := BuildFromMap(a), BuildFromMap(b)
+		var resolved = 0
+		Merge(dst, src, func(key []byte, a, b int) int {
+			resolved++
+			return a - b
+		})
+
+		if resolved != conflicts {
+			t.Errorf("resolve called %d times expected %d", resolved, conflicts)
+		}
+		if exp := BuildFromMap(expected); dst.String() != exp.String() {
+			t.Fatalf("not equal:\nexpected\n%s\ngot\n%s\n", exp, dst)
+		}
+	}
+}
+
+func ExampleMerge() {
+	routes := BuildFromMap(map[string]string{
+		"/":       "index",
+		"/login":  "login",
+		"/logout": "logout",
+	})
+	tenantRoutes := BuildFromMap(map[string]string{
+		"/":        "tenant index",
+		"/reports": "reports",
+	})
+
+	Merge(routes, tenantRoutes, func(key []byte, global, tenant string) string {
+		return tenant
+	})
+
+	for key, value := range routes.All() {
+		fmt.Println(string(key), value)
+	}
+	// Output:
+	// / tenant index
+	// /login login
+	// /logout logout
+	// /reports reports
+}
+
+func TestTrie_Graft(t *testing.T) {
+	sources := map[string]string{
+		"/":                "index",
+		"/api/user":        "user",
+		"/api/user/list":   "users list",
+		"/api/group/":      "group",
+		"/api/group/list":  "groups list",
+		"/static/logo.png": "logo",
+	}
+	tr := BuildFromMap(sources)
+	expected := tr.String()
+
+	for _, mask := range []string{"/api/", "/api/user", "/ap", "/static/logo.png", "/"} {
+		sub, ok := tr.Clone(nil).SubTrie([]byte(mask), false)
+		if !ok {
+			t.Fatalf("%q: no subtrie", mask)
+		}
+		rest := tr.Clone(nil)
+		rest.DeletePrefixString(mask)
+
+		rest.Graft([]byte(mask), sub, func(key []byte, existing, grafted string) string {
+			t.Errorf("%q: unexpected conflict for %q", mask, key)
+			return grafted
+		})
+		if rest.String() != expected {
+			t.Errorf("%q: not equal:\nexpected\n%s\ngot\n%s\n", mask, expected, rest)
+		}
+	}
+
+	modules := BuildFromMap(map[string]string{"": "users", "/list": "users list", "/new": "new user"})
+	tr.Graft([]byte("/api/user"), modules, func(key []byte, existing, grafted string) string {
+		return existing + "+" + grafted
+	})
+	sources["/api/user"] = "user+users"
+	sources["/api/user/list"] = "users list+users list"
+	sources["/api/user/new"] = "new user"
+	if exp := BuildFromMap(sources); tr.String() != exp.String() {
+		t.Errorf("not equal:\nexpected\n%s\ngot\n%s\n", exp, tr)
+	}
+}
@@ -0,0 +1,200 @@
+package trie
+
+// Min returns the smallest key stored in trie (in lexicographic order) with associated value.
+func (t *Trie[T]) Min() (key []byte, value T, ok bool) {
+	if t == nil {
+		return nil, value, false
+	}
+	return t.min(nil)
+}
+
+// Max returns the greatest key stored in trie (in lexicographic order) with associated value.
+func (t *Trie[T]) Max() (key []byte, value T, ok bool) {
+	if t == nil {
+		return nil, value, false
+	}
+	return t.max(nil)
+}
+
+// Floor returns the greatest stored key that is less than or equal to key.
+//
+//	tr := {"a": v0, "b": v1, "ba": v2}
+//
+//	tr.Floor("b")  -> "b", v1
+//	tr.Floor("az") -> "a", v0
+//	tr.Floor("")   -> not found
+func (t *Trie[T]) Floor(key []byte) (foundKey []byte, value T, ok bool) {
+	if t == nil {
+		return nil, value, false
+	}
+	return t.floor(nil, key, true)
+}
+
+// Predecessor returns the greatest stored key that is strictly less than key.
+func (t *Trie[T]) Predecessor(key []byte) (foundKey []byte, value T, ok bool) {
+	if t == nil {
+		return nil, value, false
+	}
+	return t.floor(nil, key, false)
+}
+
+// Ceiling returns the smallest stored key that is greater than or equal to key.
+//
+//	tr := {"a": v0, "b": v1, "ba": v2}
+//
+//	tr.Ceiling("b")  -> "b", v1
+//	tr.Ceiling("az") -> "b", v1
+//	tr.Ceiling("c")  -> not found
+func (t *Trie[T]) Ceiling(key []byte) (foundKey []byte, value T, ok bool) {
+	if t == nil {
+		return nil, value, false
+	}
+	return t.ceiling(nil, key, true)
+}
+
+// Successor returns the smallest stored key that is strictly greater than key.
+func (t *Trie[T]) Successor(key []byte) (foundKey []byte, value T, ok bool) {
+	if t == nil {
+		return nil, value, false
+	}
+	return t.ceiling(nil, key, false)
+}
+
+// min returns the first value of trie: own value goes before any child's.
+// path contains all bytes before t.Prefix.
+func (t *Trie[T]) min(path []byte) (key []byte, value T, ok bool) {
+	path = append(path, t.Prefix...)
+	if t.Value != nil {
+		return path, *t.Value, true
+	}
+	if t.Children != nil {
+		for i := 0; i < len(t.Children); i++ {
+			if t.Children[i] != nil {
+				if key, value, ok = t.Children[i].min(path); ok {
+					return key, value, true
+				}
+			}
+		}
+	}
+	return nil, value, false
+}
+
+// max returns the last value of trie: any child's value goes after own.
+func (t *Trie[T]) max(path []byte) (key []byte, value T, ok bool) {
+	path = append(path, t.Prefix...)
+	if t.Children != nil {
+		for i := len(t.Children) - 1; i >= 0; i-- {
+			if t.Children[i] != nil {
+				if key, value, ok = t.Children[i].max(path); ok {
+					return key, value, true
+				}
+			}
+		}
+	}
+	if t.Value != nil {
+		return path, *t.Value, true
+	}
+	return nil, value, false
+}
+
+// ceiling searches the smallest key greater than (or equal to, if inclusive) rest.
+// rest is part of searched key that is not matched yet (starting from t.Prefix).
+func (t *Trie[T]) ceiling(path []byte, rest []byte, inclusive bool) (key []byte, value T, ok bool) {
+	ind := 0
+	for ind < len(t.Prefix) && ind < len(rest) && t.Prefix[ind] == rest[ind] {
+		ind++
+	}
+
+	if ind < len(t.Prefix) {
+		if ind == len(rest) || t.Prefix[ind] > rest[ind] {
+			// all keys of this trie are greater than searched one
+			return t.min(path)
+		}
+		// all keys of this trie are less than searched one
+		return nil, value, false
+	}
+
+	path = append(path, t.Prefix...)
+	if ind == len(rest) {
+		// exact match of prefix. All children are greater than searched key
+		if inclusive && t.Value != nil {
+			return path, *t.Value, true
+		}
+		if t.Children != nil {
+			for i := 0; i < len(t.Children); i++ {
+				if t.Children[i] != nil {
+					if key, value, ok = t.Children[i].min(path); ok {
+						return key, value, true
+					}
+				}
+			}
+		}
+		return nil, value, false
+	}
+
+	// own value is less than searched key - take it from the proper child or from the next ones
+	if t.Children != nil {
+		var next = int(rest[ind])
+		if t.Children[next] != nil {
+			if key, value, ok = t.Children[next].ceiling(path, rest[ind:], inclusive); ok {
+				return key, value, true
+			}
+		}
+		for i := next + 1; i < len(t.Children); i++ {
+			if t.Children[i] != nil {
+				if key, value, ok = t.Children[i].min(path); ok {
+					return key, value, true
+				}
+			}
+		}
+	}
+	return nil, value, false
+}
+
+// floor searches the greatest key less than (or equal to, if inclusive) rest.
+// rest is part of searched key that is not matched yet (starting from t.Prefix).
+func (t *Trie[T]) floor(path []byte, rest []byte, inclusive bool) (key []byte, value T, ok bool) {
+	ind := 0
+	for ind < len(t.Prefix) && ind < len(rest) && t.Prefix[ind] == rest[ind] {
+		ind++
+	}
+
+	if ind < len(t.Prefix) {
+		if ind < len(rest) && t.Prefix[ind] < rest[ind] {
+			// all keys of this trie are less than searched one
+			return t.max(path)
+		}
+		// all keys of this trie are greater than searched one
+		return nil, value, false
+	}
+
+	path = append(path, t.Prefix...)
+	if ind == len(rest) {
+		// exact match of prefix. All children are greater than searched key
+		if inclusive && t.Value != nil {
+			return path, *t.Value, true
+		}
+		return nil, value, false
+	}
+
+	// previous children and own value are less than searched key
+	if t.Children != nil {
+		var next = int(rest[ind])
+		if t.Children[next] != nil {
+			if key, value, ok = t.Children[next].floor(path, rest[ind:], inclusive); ok {
+				return key, value, true
+			}
+		}
+		for i := next - 1; i >= 0; i-- {
+			if t.Children[i] != nil {
+				if key, value, ok = t.Children[i].max(path); ok {
+					return key, value, true
+				}
+			}
+		}
+	}
+	if t.Value != nil {
+		return path, *t.Value, true
+	}
+	return nil, value, false
+}
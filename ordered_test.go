@@ -0,0 +1,98 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTrie_Ordered(t *testing.T) {
+	var keys [][]byte
+	tr := &Trie[string]{}
+	for i := 0; i < 300; i++ {
+		var key = make([]byte, rand.Intn(5))
+		for j := range key {
+			key[j] = byte(rand.Intn(4)) * 0x40
+		}
+		if _, ok := tr.Get(key); !ok {
+			keys = append(keys, key)
+		}
+		tr.Put(key, string(key))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+
+	check := func(name string, query []byte, key []byte, value string, ok bool, expectedInd int) {
+		t.Helper()
+		if expectedInd < 0 || expectedInd >= len(keys) {
+			if ok {
+				t.Errorf("%s(%v): got %v expected nothing", name, query, key)
+			}
+			return
+		}
+		if !ok || !bytes.Equal(key, keys[expectedInd]) || value != string(keys[expectedInd]) {
+			t.Errorf("%s(%v): got %v (%t) expected %v", name, query, key, ok, keys[expectedInd])
+		}
+	}
+
+	key, value, ok := tr.Min()
+	check("Min", nil, key, value, ok, 0)
+	key, value, ok = tr.Max()
+	check("Max", nil, key, value, ok, len(keys)-1)
+
+	for i := 0; i < 300; i++ {
+		var query = make([]byte, rand.Intn(6))
+		for j := range query {
+			query[j] = byte(rand.Intn(5)) * 0x30
+		}
+		ind, found := slices.BinarySearchFunc(keys, query, bytes.Compare)
+
+		key, value, ok = tr.Ceiling(query)
+		check("Ceiling", query, key, value, ok, ind)
+
+		key, value, ok = tr.Floor(query)
+		if found {
+			check("Floor", query, key, value, ok, ind)
+		} else {
+			check("Floor", query, key, value, ok, ind-1)
+		}
+
+		key, value, ok = tr.Successor(query)
+		if found {
+			check("Successor", query, key, value, ok, ind+1)
+		} else {
+			check("Successor", query, key, value, ok, ind)
+		}
+
+		key, value, ok = tr.Predecessor(query)
+		check("Predecessor", query, key, value, ok, ind-1)
+	}
+
+	if _, _, ok := (&Trie[string]{}).Min(); ok {
+		t.Errorf("empty trie has no min")
+	}
+	if _, _, ok := (*Trie[string])(nil).Floor([]byte("a")); ok {
+		t.Errorf("nil trie has no floor")
+	}
+}
+
+func ExampleTrie_Floor() {
+	snapshots := BuildFromMap(map[string]string{
+		"2021-03-01": "v1",
+		"2021-06-15": "v2",
+		"2022-01-10": "v3",
+	})
+
+	for _, date := range []string{"2021-07-01", "2022-01-10", "2020-12-31"} {
+		if key, value, ok := snapshots.Floor([]byte(date)); ok {
+			fmt.Printf("%s: snapshot %s from %s\n", date, value, key)
+		} else {
+			fmt.Printf("%s: no snapshot\n", date)
+		}
+	}
+	// Output:
+	// 2021-07-01: snapshot v2 from 2021-06-15
+	// 2022-01-10: snapshot v3 from 2022-01-10
+	// 2020-12-31: no snapshot
+}
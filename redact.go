@@ -0,0 +1,111 @@
+package trie
+
+import "io"
+
+// RedactingWriter replaces stored keys (secrets, banned words, etc.) in data written through it
+// and passes result to underlying writer.
+// Keys are searched the same way as Replacer does: from left to right, taking the longest key at every position.
+//
+// Keys that are split between several Write calls are found too. For this purpose writer holds back the tail
+// of written data that can be the beginning of some key (so it is shorter than the longest key) until
+// next Write, Flush or Close.
+//
+//	w := NewRedactingWriter(os.Stdout, secrets, nil)
+//	defer w.Close()
+//
+//	w.Write([]byte("token=sec"))
+//	w.Write([]byte("ret\n"))
+//	-> token=******
+//
+// Trie should not be modified while writer is used. Not thread safe.
+type RedactingWriter[T any] struct {
+	w       io.Writer
+	trie    *Trie[T]
+	replace func(key []byte, value T) []byte
+	err     error
+
+	// pending is a tail of written data that is not processed yet
+	pending []byte
+	// out is reused for processed data
+	out []byte
+}
+
+// NewRedactingWriter creates RedactingWriter that writes to w.
+// Every found key is replaced with result of replace (which receives found key and associated value).
+// If replace is nil - every byte of found key is replaced with '*'.
+func NewRedactingWriter[T any](w io.Writer, t *Trie[T], replace func(key []byte, value T) []byte) *RedactingWriter[T] {
+	return &RedactingWriter[T]{
+		w:       w,
+		trie:    t,
+		replace: replace,
+	}
+}
+
+// Write processes p and writes result to underlying writer.
+// Beginning of possible key at the end of p is held back until the next call.
+// If underlying writer returns error - it is returned by all following calls.
+func (r *RedactingWriter[T]) Write(p []byte) (n int, err error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	r.pending = append(r.pending, p...)
+	if err = r.redact(false); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush processes all held back data and writes it to underlying writer.
+// Keys that could be completed by following writes are not searched anymore.
+func (r *RedactingWriter[T]) Flush() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.redact(true)
+}
+
+// Close flushes held back data. Underlying writer is not closed.
+func (r *RedactingWriter[T]) Close() error {
+	return r.Flush()
+}
+
+// redact writes processed part of pending data to underlying writer.
+// If final is false - processing stops at the first position where key can be continued by the next write.
+func (r *RedactingWriter[T]) redact(final bool) error {
+	var input, out = r.pending, r.out[:0]
+	var last, pos = 0, 0
+	for pos < len(input) {
+		value, prefixLen, ok, more := r.trie.searchPrefixPartial(input[pos:])
+		if more && !final {
+			// wait for more data
+			break
+		}
+		if !ok {
+			pos++
+			continue
+		}
+
+		out = append(out, input[last:pos]...)
+		if r.replace == nil {
+			for i := 0; i < prefixLen; i++ {
+				out = append(out, '*')
+			}
+		} else {
+			out = append(out, r.replace(input[pos:pos+prefixLen], value)...)
+		}
+		pos += prefixLen
+		last = pos
+	}
+	out = append(out, input[last:pos]...)
+
+	r.out = out
+	r.pending = append(r.pending[:0], input[pos:]...)
+
+	if len(out) > 0 {
+		if _, err := r.w.Write(out); err != nil {
+			r.err = err
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestRedactingWriter__Random(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		var tr = &Trie[string]{}
+		var replacements = &Trie[string]{}
+		for j := 0; j < rand.Intn(10); j++ {
+			var key = make([]byte, 1+rand.Intn(4))
+			for k := range key {
+				key[k] = "abc"[rand.Intn(3)]
+			}
+			tr.Put(key, string(key))
+			replacements.Put(key, "<"+string(key)+">")
+		}
+
+		var input = make([]byte, rand.Intn(30))
+		for k := range input {
+			input[k] = "abcd"[rand.Intn(4)]
+		}
+		var expected = NewReplacer(replacements).Replace(input)
+
+		var buf bytes.Buffer
+		var w = NewRedactingWriter(&buf, tr, func(key []byte, value string) []byte {
+			if string(key) != value {
+				t.Errorf("key %q doesn't match value %q", key, value)
+			}
+			return []byte("<" + value + ">")
+		})
+		for rest := input; len(rest) > 0; {
+			var l = min(rand.Intn(5), len(rest))
+			if n, err := w.Write(rest[:l]); n != l || err != nil {
+				t.Fatalf("unexpected write result (%d, %v)", n, err)
+			}
+			rest = rest[l:]
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if buf.String() != string(expected) {
+			t.Errorf("%v: got %q expected %q (input %q)", tr, buf.String(), expected, input)
+		}
+	}
+}
+
+func TestRedactingWriter(t *testing.T) {
+	tr := BuildFromMap(map[string]bool{
+		"secret":  true,
+		"secrets": true,
+		"":        true,
+	})
+
+	var buf bytes.Buffer
+	var w = NewRedactingWriter(&buf, tr, nil)
+	_, _ = w.Write([]byte("my sec"))
+	if buf.String() != "my " {
+		t.Errorf("possible key should be held back, got %q", buf.String())
+	}
+	_, _ = w.Write([]byte("ret"))
+	if buf.String() != "my " {
+		t.Errorf("key can be continued, got %q", buf.String())
+	}
+	_, _ = w.Write([]byte(" is."))
+	if buf.String() != "my ****** is." {
+		t.Errorf("got %q", buf.String())
+	}
+	_, _ = w.Write([]byte(" secrets secre"))
+	_ = w.Flush()
+	if buf.String() != "my ****** is. ******* secre" {
+		t.Errorf("got %q", buf.String())
+	}
+
+	var failing = NewRedactingWriter(errorWriter{}, tr, nil)
+	if n, err := failing.Write([]byte("some")); n != 0 || err == nil {
+		t.Errorf("error expected, got (%d, %v)", n, err)
+	}
+	if err := failing.Close(); err == nil {
+		t.Errorf("error expected")
+	}
+}
+
+type errorWriter struct{}
+
+func (errorWriter) Write([]byte) (int, error) {
+	return 0, errors.New("failed")
+}
+
+func ExampleRedactingWriter() {
+	secrets := BuildFromMap(map[string]string{
+		"hunter2": "PASSWORD",
+		"ak_1234": "API_KEY",
+	})
+	w := NewRedactingWriter(os.Stdout, secrets, func(key []byte, value string) []byte {
+		return []byte("[" + value + "]")
+	})
+
+	_, _ = w.Write([]byte("login: admin, password: hun"))
+	_, _ = w.Write([]byte("ter2, key: ak_12"))
+	_, _ = w.Write([]byte("34\n"))
+	_ = w.Close()
+	// Output:
+	// login: admin, password: [PASSWORD], key: [API_KEY]
+}
@@ -0,0 +1,98 @@
+package trie
+
+import "io"
+
+// Replacer replaces every stored key found in input with associated value (like strings.Replacer).
+// Keys are searched from left to right, and of keys starting at the same position the longest one is taken
+// (the same as SearchPrefixIn). Replaced parts are not searched again. Empty key is ignored.
+//
+// Unlike strings.Replacer it reads trie on every call, so trie can be updated between calls
+// (e.g. with Put or Delete), and keys can be arbitrary bytes.
+// Replacer is safe for concurrent use as long as trie is not modified concurrently.
+type Replacer[T ~[]byte | ~string] struct {
+	trie *Trie[T]
+}
+
+// NewReplacer creates Replacer that uses t. Trie is not copied.
+func NewReplacer[T ~[]byte | ~string](t *Trie[T]) *Replacer[T] {
+	return &Replacer[T]{trie: t}
+}
+
+// Replace returns copy of input with all replacements performed.
+func (r *Replacer[T]) Replace(input []byte) []byte {
+	return r.appendReplaced(make([]byte, 0, len(input)), input)
+}
+
+// ReplaceString returns copy of s with all replacements performed.
+func (r *Replacer[T]) ReplaceString(s string) string {
+	return string(r.appendReplaced(make([]byte, 0, len(s)), []byte(s)))
+}
+
+// WriteString writes s to w with all replacements performed (like strings.Replacer.WriteString).
+// Result is written part by part and is not collected in memory.
+//
+// There is no WriteTo method: io.WriterTo defines WriteTo(w) without input, so a method with the same name,
+// but another signature, would be confusing (and is reported by go vet).
+func (r *Replacer[T]) WriteString(w io.Writer, s string) (n int, err error) {
+	return r.WriteBytes(w, []byte(s))
+}
+
+// WriteBytes writes input to w with all replacements performed.
+// Result is written part by part and is not collected in memory.
+func (r *Replacer[T]) WriteBytes(w io.Writer, input []byte) (n int, err error) {
+	var written int
+	err = r.replace(input, func(unmatched []byte, value T, matched bool) error {
+		if len(unmatched) > 0 {
+			written, err = w.Write(unmatched)
+			n += written
+			if err != nil {
+				return err
+			}
+		}
+		if matched {
+			switch v := any(value).(type) {
+			case string:
+				written, err = io.WriteString(w, v)
+			default:
+				written, err = w.Write([]byte(value))
+			}
+			n += written
+		}
+		return err
+	})
+	return n, err
+}
+
+func (r *Replacer[T]) appendReplaced(dst []byte, input []byte) []byte {
+	_ = r.replace(input, func(unmatched []byte, value T, matched bool) error {
+		dst = append(dst, unmatched...)
+		if matched {
+			dst = append(dst, value...)
+		}
+		return nil
+	})
+	return dst
+}
+
+// replace calls emit for every found key with preceding unmatched part of input and associated value,
+// and for the rest of input after the last key (with matched == false).
+func (r *Replacer[T]) replace(input []byte, emit func(unmatched []byte, value T, matched bool) error) error {
+	var last = 0
+	if r.trie != nil {
+		for pos := 0; pos < len(input); {
+			value, prefixLen, ok := r.trie.SearchPrefixIn(input[pos:])
+			if !ok || prefixLen == 0 {
+				pos++
+				continue
+			}
+			if err := emit(input[last:pos], value, true); err != nil {
+				return err
+			}
+			pos += prefixLen
+			last = pos
+		}
+	}
+
+	var value T
+	return emit(input[last:], value, false)
+}
@@ -0,0 +1,79 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReplacer(t *testing.T) {
+	tr := BuildFromMap(map[string]string{
+		"a":   "1",
+		"ab":  "2",
+		"abc": "3",
+		"b":   "",
+		"":    "empty",
+	})
+	r := NewReplacer(tr)
+
+	var inputs = map[string]string{
+		"":           "",
+		"xyz":        "xyz",
+		"abcabxaby":  "32x2y",
+		"bbbx":       "x",
+		"aaa":        "111",
+		"xabcdabbac": "x3d21c",
+	}
+
+	for input, expected := range inputs {
+		if got := r.ReplaceString(input); got != expected {
+			t.Errorf("%q: got %q expected %q", input, got, expected)
+		}
+		if got := r.Replace([]byte(input)); string(got) != expected {
+			t.Errorf("%q: got %q expected %q", input, got, expected)
+		}
+		var buf bytes.Buffer
+		if n, err := r.WriteString(&buf, input); err != nil || n != len(expected) || buf.String() != expected {
+			t.Errorf("%q: got (%q, %d, %v) expected %q", input, buf.String(), n, err, expected)
+		}
+	}
+
+	// trie updates are visible to replacer
+	tr.PutString("x", "X")
+	if got := r.ReplaceString("xabcdabbac"); got != "X3d21c" {
+		t.Errorf("got %q expected %q", got, "X3d21c")
+	}
+
+	bytesReplacer := NewReplacer(BuildFromMap(map[string][]byte{"\x00": []byte("\\0")}))
+	if got := bytesReplacer.Replace([]byte("a\x00b")); string(got) != "a\\0b" {
+		t.Errorf("got %q expected %q", got, "a\\0b")
+	}
+
+	// replaces the same way as strings.Replacer for non overlapping keys
+	pairs := []string{"&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;"}
+	html := &Trie[string]{}
+	for i := 0; i < len(pairs); i += 2 {
+		html.PutString(pairs[i], pairs[i+1])
+	}
+	var input = `<a href="/?a=1&b=2">link</a>`
+	if got, expected := NewReplacer(html).ReplaceString(input), strings.NewReplacer(pairs...).Replace(input); got != expected {
+		t.Errorf("got %q expected %q", got, expected)
+	}
+}
+
+func ExampleReplacer() {
+	shortcodes := BuildFromMap(map[string]string{
+		"😀":  ":grinning:",
+		"👍":  ":+1:",
+		"👍🏻": ":+1::skin-tone-1:",
+	})
+	r := NewReplacer(shortcodes)
+
+	fmt.Println(r.ReplaceString("Nice 👍🏻 and 👍 😀"))
+	_, _ = r.WriteString(os.Stdout, "Done 👍\n")
+	// Output:
+	// Nice :+1::skin-tone-1: and :+1: :grinning:
+	// Done :+1:
+}
@@ -0,0 +1,57 @@
+package trie
+
+import "bufio"
+
+// SplitFunc returns split function for bufio.Scanner that splits input into tokens by stored keys.
+// Every token is either the longest stored key found at current position (like SearchPrefixIn) or a part
+// of the run of bytes, where no key starts. Runs are split into tokens by fallback (it receives the whole run
+// at once, and atEOF is true if the run is known to be complete). If fallback is nil - every run is returned
+// as is, and long runs are returned part by part (as soon as they are read), so they can be longer
+// than scanner's buffer.
+// Empty key is ignored.
+//
+// If data ends in the middle of possible key, split function asks scanner for more data,
+// so input of any size is processed without loading it into memory.
+//
+//	tr := {"👍": ..., "😀": ...}
+//
+//	scanner := bufio.NewScanner(strings.NewReader("hello 👍😀 world"))
+//	scanner.Split(SplitFunc(tr, bufio.ScanWords))
+//	-> "hello", "👍", "😀", "world"
+//
+// Trie should not be modified while scanner is used.
+func SplitFunc[T any](t *Trie[T], fallback bufio.SplitFunc) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		// search the end of the run of unmatched bytes
+		var end, complete = 0, atEOF
+		for end < len(data) {
+			_, prefixLen, ok, more := t.searchPrefixPartial(data[end:])
+			if more && !atEOF {
+				// key can start here - we need more data to decide
+				break
+			}
+			if ok {
+				if end == 0 {
+					return prefixLen, data[:prefixLen], nil
+				}
+				complete = true
+				break
+			}
+			end++
+		}
+
+		if end == 0 {
+			// request more data
+			return 0, nil, nil
+		}
+		if fallback != nil {
+			return fallback(data[:end], complete)
+		}
+		// no key starts before end, so the run can be returned even if it is continued further
+		return end, data[:end], nil
+	}
+}
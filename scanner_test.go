@@ -0,0 +1,146 @@
+package trie
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func scanAll(t *testing.T, scanner *bufio.Scanner) []string {
+	var res []string
+	for scanner.Scan() {
+		res = append(res, scanner.Text())
+	}
+	if scanner.Err() != nil {
+		t.Fatalf("unexpected error %v", scanner.Err())
+	}
+	return res
+}
+
+// joinRuns joins adjacent parts of runs, that can be returned separately depending on how input is read
+func joinRuns[T any](tr *Trie[T], tokens []string) []string {
+	var res []string
+	var lastIsRun = false
+	for _, token := range tokens {
+		_, isKey := tr.GetByString(token)
+		if !isKey && lastIsRun {
+			res[len(res)-1] += token
+		} else {
+			res = append(res, token)
+		}
+		lastIsRun = !isKey
+	}
+	return res
+}
+
+func TestSplitFunc(t *testing.T) {
+	tr := BuildFromMap(map[string]int{
+		"ab":  1,
+		"abc": 2,
+		"bcd": 3,
+		"":    4,
+	})
+
+	cases := []struct {
+		input    string
+		fallback bufio.SplitFunc
+		expected []string
+	}{
+		{"", nil, nil},
+		{"xyz", nil, []string{"xyz"}},
+		{"ab", nil, []string{"ab"}},
+		{"abcd", nil, []string{"abc", "d"}},
+		{"xabdbcdab", nil, []string{"x", "ab", "d", "bcd", "ab"}},
+		{"xxbcxabc", nil, []string{"xxbcx", "abc"}},
+		{"hello abc world", bufio.ScanWords, []string{"hello", "abc", "world"}},
+		{"a b ab c", bufio.ScanWords, []string{"a", "b", "ab", "c"}},
+		{"xy", bufio.ScanRunes, []string{"x", "y"}},
+	}
+
+	for _, c := range cases {
+		// reading by single byte checks that incomplete keys and runs are handled properly
+		scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(c.input)))
+		scanner.Split(SplitFunc(tr, c.fallback))
+		var res = scanAll(t, scanner)
+		if c.fallback == nil {
+			res = joinRuns(tr, res)
+		}
+		if fmt.Sprint(res) != fmt.Sprint(c.expected) {
+			t.Errorf("%q: got %q expected %q", c.input, res, c.expected)
+		}
+	}
+}
+
+func TestSplitFunc__Random(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		var tr = &Trie[bool]{}
+		for j := 0; j < rand.Intn(10); j++ {
+			var key = make([]byte, rand.Intn(4))
+			for k := range key {
+				key[k] = "abc"[rand.Intn(3)]
+			}
+			tr.Put(key, true)
+		}
+		var input = make([]byte, rand.Intn(40))
+		for k := range input {
+			input[k] = "abcd"[rand.Intn(4)]
+		}
+
+		// tokens should not depend on how input is read (except for splitting of runs)
+		whole := bufio.NewScanner(bytes.NewReader(input))
+		whole.Split(SplitFunc(tr, nil))
+		byByte := bufio.NewScanner(iotest.OneByteReader(bytes.NewReader(input)))
+		byByte.Split(SplitFunc(tr, nil))
+
+		expected, res := scanAll(t, whole), scanAll(t, byByte)
+		if strings.Join(res, "") != string(input) {
+			t.Errorf("%v %q: tokens %q don't cover input", tr, input, res)
+		}
+		expected, res = joinRuns(tr, expected), joinRuns(tr, res)
+		if fmt.Sprint(res) != fmt.Sprint(expected) {
+			t.Errorf("%v %q: got %q expected %q", tr, input, res, expected)
+		}
+	}
+}
+
+func TestSplitFunc__LongRun(t *testing.T) {
+	tr := BuildPrefixesOnly("key")
+
+	// run is longer than scanner's buffer
+	var run = strings.Repeat("x", bufio.MaxScanTokenSize+100000)
+	scanner := bufio.NewScanner(strings.NewReader(run + "key" + run))
+	scanner.Split(SplitFunc(tr, nil))
+
+	res := joinRuns(tr, scanAll(t, scanner))
+	if len(res) != 3 || res[0] != run || res[1] != "key" || res[2] != run {
+		t.Errorf("unexpected tokens (%d)", len(res))
+	}
+}
+
+func ExampleSplitFunc() {
+	emojis := BuildFromMap(map[string]string{
+		"👍":  "thumbs up",
+		"👍🏻": "thumbs up: light skin tone",
+		"😀":  "grinning face",
+	})
+
+	scanner := bufio.NewScanner(strings.NewReader("nice 👍🏻😀 work 👍"))
+	scanner.Split(SplitFunc(emojis, bufio.ScanWords))
+	for scanner.Scan() {
+		if name, ok := emojis.Get(scanner.Bytes()); ok {
+			fmt.Printf("%s (%s)\n", scanner.Text(), name)
+		} else {
+			fmt.Println(scanner.Text())
+		}
+	}
+	// Output:
+	// nice
+	// 👍🏻 (thumbs up: light skin tone)
+	// 😀 (grinning face)
+	// work
+	// 👍 (thumbs up)
+}
@@ -0,0 +1,243 @@
+package trie
+
+// Set operations walk both tries node by node and build result from nodes of arguments,
+// so keys are never put one by one. Arguments are not modified.
+// Result doesn't share nodes with arguments, but shares prefixes and values
+// (they are never modified in place, so it's safe).
+//
+// All operations work for any value type, but usually are used with prefix sets built by BuildPrefixesOnly.
+// For keys present in both tries value from a is taken.
+
+// Union returns trie with keys present in a or in b.
+func Union[T any](a, b *Trie[T]) *Trie[T] {
+	var res = orEmpty(copyFrom(a, 0))
+	Merge(res, copyFrom(b, 0), func(key []byte, a, b T) T {
+		return a
+	})
+	return res
+}
+
+// Intersect returns trie with keys present both in a and in b.
+func Intersect[T any](a, b *Trie[T]) *Trie[T] {
+	return orEmpty(intersect(a, 0, b, 0))
+}
+
+// Difference returns trie with keys present in a, but not in b.
+func Difference[T any](a, b *Trie[T]) *Trie[T] {
+	return orEmpty(difference(a, 0, b, 0))
+}
+
+// SymmetricDifference returns trie with keys present only in one of a and b.
+func SymmetricDifference[T any](a, b *Trie[T]) *Trie[T] {
+	var res = orEmpty(difference(a, 0, b, 0))
+	Merge(res, difference(b, 0, a, 0), nil) // there are no common keys
+	return res
+}
+
+// IsSubset reports whether all keys of a are present in b.
+func IsSubset[T any](a, b *Trie[T]) bool {
+	return isSubset(a, 0, b, 0)
+}
+
+// intersect returns intersection of a (starting from a.Prefix[aOff:]) and b (starting from b.Prefix[bOff:])
+// or nil if it is empty.
+func intersect[T any](a *Trie[T], aOff int, b *Trie[T], bOff int) *Trie[T] {
+	if a == nil || b == nil {
+		return nil
+	}
+	if a == b && aOff == bOff {
+		return copyFrom(a, aOff)
+	}
+
+	var aPrefix, bPrefix = a.Prefix[aOff:], b.Prefix[bOff:]
+	ind := 0
+	for ind < len(aPrefix) && ind < len(bPrefix) && aPrefix[ind] == bPrefix[ind] {
+		ind++
+	}
+
+	switch {
+	case ind < len(aPrefix) && ind < len(bPrefix):
+		// prefixes diverged
+		return nil
+	case ind < len(bPrefix):
+		// a is exhausted - only one of it's children continues with b
+		return withPrefix(aPrefix, intersect(a.child(int(bPrefix[ind])), 0, b, bOff+ind))
+	case ind < len(aPrefix):
+		// b is exhausted - only one of it's children continues with a
+		return withPrefix(bPrefix, intersect(a, aOff+ind, b.child(int(aPrefix[ind])), 0))
+	}
+
+	var res = &Trie[T]{Prefix: aPrefix}
+	if a.Value != nil && b.Value != nil {
+		res.Value = a.Value
+	}
+	if a.Children != nil && b.Children != nil {
+		for i := range a.Children {
+			res.setChild(i, intersect(a.Children[i], 0, b.Children[i], 0))
+		}
+	}
+	return res.normalized()
+}
+
+// difference returns a (starting from a.Prefix[aOff:]) without keys of b (starting from b.Prefix[bOff:])
+// or nil if it is empty.
+func difference[T any](a *Trie[T], aOff int, b *Trie[T], bOff int) *Trie[T] {
+	if a == nil || a == b && aOff == bOff {
+		return nil
+	}
+	if b == nil {
+		return copyFrom(a, aOff)
+	}
+
+	var aPrefix, bPrefix = a.Prefix[aOff:], b.Prefix[bOff:]
+	ind := 0
+	for ind < len(aPrefix) && ind < len(bPrefix) && aPrefix[ind] == bPrefix[ind] {
+		ind++
+	}
+
+	switch {
+	case ind < len(aPrefix) && ind < len(bPrefix):
+		// prefixes diverged - nothing to subtract
+		return copyFrom(a, aOff)
+	case ind < len(bPrefix):
+		// a is exhausted - b affects only one of it's children
+		var res = &Trie[T]{Prefix: aPrefix, Value: a.Value}
+		var next = int(bPrefix[ind])
+		if a.Children != nil {
+			for i, c := range a.Children {
+				if i == next {
+					res.setChild(i, difference(c, 0, b, bOff+ind))
+				} else {
+					res.setChild(i, copyFrom(c, 0))
+				}
+			}
+		}
+		return res.normalized()
+	case ind < len(aPrefix):
+		// b is exhausted - only one of it's children continues with a
+		return withPrefix(aPrefix[:ind], difference(a, aOff+ind, b.child(int(aPrefix[ind])), 0))
+	}
+
+	var res = &Trie[T]{Prefix: aPrefix}
+	if b.Value == nil {
+		res.Value = a.Value
+	}
+	if a.Children != nil {
+		for i, c := range a.Children {
+			res.setChild(i, difference(c, 0, b.child(i), 0))
+		}
+	}
+	return res.normalized()
+}
+
+// isSubset checks whether all keys of a (starting from a.Prefix[aOff:]) are present in b (starting from b.Prefix[bOff:])
+func isSubset[T any](a *Trie[T], aOff int, b *Trie[T], bOff int) bool {
+	if a == nil || a == b && aOff == bOff {
+		return true
+	}
+	if b == nil {
+		return a.isEmpty()
+	}
+
+	var aPrefix, bPrefix = a.Prefix[aOff:], b.Prefix[bOff:]
+	ind := 0
+	for ind < len(aPrefix) && ind < len(bPrefix) && aPrefix[ind] == bPrefix[ind] {
+		ind++
+	}
+
+	switch {
+	case ind < len(aPrefix) && ind < len(bPrefix):
+		// prefixes diverged
+		return a.isEmpty()
+	case ind < len(bPrefix):
+		// a is exhausted - b has no value here and has only one child
+		if a.Value != nil {
+			return false
+		}
+		var next = int(bPrefix[ind])
+		if a.Children != nil {
+			for i, c := range a.Children {
+				if i == next && !isSubset(c, 0, b, bOff+ind) || i != next && !c.isEmpty() {
+					return false
+				}
+			}
+		}
+		return true
+	case ind < len(aPrefix):
+		// b is exhausted - only one of it's children continues with a
+		return isSubset(a, aOff+ind, b.child(int(aPrefix[ind])), 0)
+	}
+
+	if a.Value != nil && b.Value == nil {
+		return false
+	}
+	if a.Children != nil {
+		for i, c := range a.Children {
+			if !isSubset(c, 0, b.child(i), 0) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// copyFrom copies all nodes of t starting from t.Prefix[off:]. Prefixes and values are shared.
+func copyFrom[T any](t *Trie[T], off int) *Trie[T] {
+	if t == nil {
+		return nil
+	}
+	var res = &Trie[T]{Prefix: t.Prefix[off:], Value: t.Value}
+	if t.Children != nil {
+		for i, c := range t.Children {
+			res.setChild(i, copyFrom(c, 0))
+		}
+	}
+	return res
+}
+
+// withPrefix prepends prefix to t's own prefix. t should be newly built node.
+func withPrefix[T any](prefix []byte, t *Trie[T]) *Trie[T] {
+	if t == nil || len(prefix) == 0 {
+		return t
+	}
+	var newPrefix = make([]byte, len(prefix)+len(t.Prefix))
+	copy(newPrefix, prefix)
+	copy(newPrefix[len(prefix):], t.Prefix)
+	t.Prefix = newPrefix
+	return t
+}
+
+// setChild sets child with specified index (if it is not nil), creating Children if needed
+func (t *Trie[T]) setChild(ind int, child *Trie[T]) {
+	if child == nil {
+		return
+	}
+	if t.Children == nil {
+		t.Children = &[256]*Trie[T]{}
+	}
+	t.Children[ind] = child
+}
+
+// normalized returns nil for node without value and children,
+// or merges node without value with it's only child.
+func (t *Trie[T]) normalized() *Trie[T] {
+	if t.Value != nil {
+		return t
+	}
+	child, count := t.onlyChild()
+	switch count {
+	case 0:
+		return nil
+	case 1:
+		t.mergeWith(child)
+	}
+	return t
+}
+
+// orEmpty returns empty trie instead of nil
+func orEmpty[T any](t *Trie[T]) *Trie[T] {
+	if t == nil {
+		return &Trie[T]{}
+	}
+	return t
+}
@@ -0,0 +1,84 @@
+package trie
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestSetOperations(t *testing.T) {
+	randomMap := func(value int) map[string]int {
+		var res = make(map[string]int)
+		for i := 0; i < rand.Intn(100); i++ {
+			var b = make([]byte, rand.Intn(6))
+			for j := range b {
+				b[j] = "abc"[rand.Intn(3)]
+			}
+			res[string(b)] = value
+		}
+		return res
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := randomMap(1), randomMap(2)
+		aTrie, bTrie := BuildFromMap(a), BuildFromMap(b)
+		aString, bString := aTrie.String(), bTrie.String()
+
+		var union, intersection, difference, symmetric = map[string]int{}, map[string]int{}, map[string]int{}, map[string]int{}
+		var subset = true
+		for key, value := range b {
+			union[key] = value
+			if _, ok := a[key]; !ok {
+				symmetric[key] = value
+			}
+		}
+		for key, value := range a {
+			union[key] = value
+			if _, ok := b[key]; ok {
+				intersection[key] = value
+			} else {
+				difference[key] = value
+				symmetric[key] = value
+				subset = false
+			}
+		}
+
+		check := func(name string, got *Trie[int], expected map[string]int) {
+			t.Helper()
+			if exp := BuildFromMap(expected); got.String() != exp.String() {
+				t.Fatalf("%s: not equal:\nexpected\n%s\ngot\n%s\n", name, exp, got)
+			}
+		}
+		check("Union", Union(aTrie, bTrie), union)
+		check("Intersect", Intersect(aTrie, bTrie), intersection)
+		check("Difference", Difference(aTrie, bTrie), difference)
+		check("SymmetricDifference", SymmetricDifference(aTrie, bTrie), symmetric)
+		check("Intersect with itself", Intersect(aTrie, aTrie), a)
+		check("Difference with itself", Difference(aTrie, aTrie), nil)
+
+		if IsSubset(aTrie, bTrie) != subset {
+			t.Errorf("IsSubset: got %t expected %t", !subset, subset)
+		}
+		if !IsSubset(Intersect(aTrie, bTrie), aTrie) || !IsSubset(aTrie, Union(aTrie, bTrie)) {
+			t.Errorf("IsSubset: intersection and union are not consistent")
+		}
+
+		if aTrie.String() != aString || bTrie.String() != bString {
+			t.Fatalf("arguments were modified")
+		}
+	}
+}
+
+func ExampleIntersect() {
+	allowed := BuildPrefixesOnly("/api/", "/static/", "/health")
+	requested := BuildPrefixesOnly("/api/", "/admin/", "/health", "/metrics")
+
+	for key := range Intersect(allowed, requested).Keys() {
+		fmt.Println(string(key))
+	}
+	fmt.Println(IsSubset(BuildPrefixesOnly("/api/", "/health"), allowed))
+	// Output:
+	// /api/
+	// /health
+	// true
+}
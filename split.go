@@ -0,0 +1,82 @@
+package trie
+
+import "bytes"
+
+// Split divides trie into two: left with keys less than key, and right with keys greater than or equal to key.
+// Only nodes along the path to key are cut, all other nodes are moved into results as is.
+// After Split t becomes empty.
+//
+//	tr := {"a": v0, "b": v1, "ba": v2, "c": v3}
+//
+//	tr.Split("b")
+//	-> {"a": v0}, {"b": v1, "ba": v2, "c": v3}
+func (t *Trie[T]) Split(key []byte) (left, right *Trie[T]) {
+	var root = &Trie[T]{Prefix: t.Prefix, Value: t.Value, Children: t.Children}
+	left, right = splitAt(root, key)
+
+	t.Prefix, t.Value, t.Children = nil, nil, nil
+	t.modified()
+
+	return orEmpty(left), orEmpty(right)
+}
+
+// splitAt splits t by key (starting from t.Prefix). Any of results can be nil if it is empty.
+func splitAt[T any](t *Trie[T], key []byte) (left, right *Trie[T]) {
+	ind := 0
+	for ind < len(t.Prefix) && ind < len(key) && t.Prefix[ind] == key[ind] {
+		ind++
+	}
+
+	if ind < len(t.Prefix) {
+		if ind == len(key) || t.Prefix[ind] > key[ind] {
+			// all keys of t are greater than key
+			return nil, t
+		}
+		// all keys of t are less than key
+		return t, nil
+	}
+	if ind == len(key) {
+		// own value is equal to key and children are greater
+		return nil, t
+	}
+
+	// own value is less than key, children are divided by next byte of key
+	var next = int(key[ind])
+	left = &Trie[T]{Prefix: t.Prefix, Value: t.Value}
+	right = &Trie[T]{Prefix: t.Prefix}
+	if t.Children != nil {
+		for i, c := range t.Children {
+			switch {
+			case c == nil:
+				continue
+			case i < next:
+				left.setChild(i, c)
+			case i > next:
+				right.setChild(i, c)
+			default:
+				l, r := splitAt(c, key[ind:])
+				left.setChild(i, l)
+				right.setChild(i, r)
+			}
+		}
+	}
+	return left.normalized(), right.normalized()
+}
+
+// Join combines two tries whose key ranges don't overlap: all keys of left must be less than all keys of right
+// (e.g. results of Split). Like Merge it walks only nodes along the border of both tries.
+// Result is stored in left, and right must not be used afterwards.
+//
+// If ranges overlap - nothing is changed and ok is false (use Merge to combine such tries).
+func Join[T any](left, right *Trie[T]) (joined *Trie[T], ok bool) {
+	if left == nil {
+		return right, true
+	}
+	if maxKey, _, found := left.Max(); found {
+		if minKey, _, found := right.Min(); found && bytes.Compare(maxKey, minKey) >= 0 {
+			return left, false
+		}
+	}
+	Merge(left, right, nil)
+	return left, true
+}
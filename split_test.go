@@ -0,0 +1,78 @@
+package trie
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestTrie_Split(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		var sources = make(map[string]int)
+		for j := 0; j < rand.Intn(100); j++ {
+			var b = make([]byte, rand.Intn(6))
+			for k := range b {
+				b[k] = "abc"[rand.Intn(3)]
+			}
+			sources[string(b)] = j
+		}
+		var key = make([]byte, rand.Intn(6))
+		for k := range key {
+			key[k] = "abcd"[rand.Intn(4)]
+		}
+
+		var less, greater = map[string]int{}, map[string]int{}
+		for k, v := range sources {
+			if k < string(key) {
+				less[k] = v
+			} else {
+				greater[k] = v
+			}
+		}
+
+		tr := BuildFromMap(sources)
+		expected := tr.String()
+		left, right := tr.Split(key)
+
+		if exp := BuildFromMap(less); left.String() != exp.String() {
+			t.Fatalf("%q: left not equal:\nexpected\n%s\ngot\n%s\n", key, exp, left)
+		}
+		if exp := BuildFromMap(greater); right.String() != exp.String() {
+			t.Fatalf("%q: right not equal:\nexpected\n%s\ngot\n%s\n", key, exp, right)
+		}
+		if tr.Count() != 0 {
+			t.Errorf("trie should be empty after Split")
+		}
+
+		if joined, ok := Join(left, right); !ok || joined.String() != expected {
+			t.Fatalf("%q: joined not equal:\nexpected\n%s\ngot\n%s\n", key, expected, joined)
+		}
+	}
+}
+
+func TestJoin__Overlap(t *testing.T) {
+	left, right := BuildPrefixesOnly("a", "c"), BuildPrefixesOnly("b")
+	if joined, ok := Join(left, right); ok || joined != left {
+		t.Errorf("Join of overlapping tries should fail")
+	}
+	if left.Count() != 2 || right.Count() != 1 {
+		t.Errorf("tries should not be changed by failed Join")
+	}
+}
+
+func ExampleTrie_Split() {
+	dictionary := BuildPrefixesOnly("apple", "banana", "cherry", "date")
+
+	left, right := dictionary.Split([]byte("c"))
+	for key := range left.Keys() {
+		fmt.Println("left:", string(key))
+	}
+	for key := range right.Keys() {
+		fmt.Println("right:", string(key))
+	}
+	// Output:
+	// left: apple
+	// left: banana
+	// right: cherry
+	// right: date
+}
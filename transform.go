@@ -0,0 +1,76 @@
+package trie
+
+// MapValues returns new trie with the same keys and values converted by f.
+// Layout of t is reused: nodes are copied with their prefixes, so keys are not inserted one by one.
+// Key passed to f is reused - copy it if you need it after f returns.
+//
+//	handlers := trie.MapValues(configs, func(key []byte, cfg RouteConfig) http.Handler {
+//		return cfg.Handler()
+//	})
+func MapValues[T, U any](t *Trie[T], f func(key []byte, value T) U) *Trie[U] {
+	if t == nil {
+		return &Trie[U]{}
+	}
+	return mapValues(t, make([]byte, 0, 64), f)
+}
+
+func mapValues[T, U any](t *Trie[T], path []byte, f func(key []byte, value T) U) *Trie[U] {
+	path = append(path, t.Prefix...)
+
+	var res = &Trie[U]{Prefix: t.Prefix}
+	if t.Value != nil {
+		var value = f(path, *t.Value)
+		res.Value = &value
+	}
+	if t.Children != nil {
+		for i, c := range t.Children {
+			if c != nil {
+				res.setChild(i, mapValues(c, path, f))
+			}
+		}
+	}
+	return res
+}
+
+// Filter returns new trie only with entries for which pred returns true.
+// Subtrees that become empty are pruned and nodes are merged, so result has the same shape
+// as if it was built from remaining entries. t is not modified.
+// Key passed to pred is reused - copy it if you need it after pred returns.
+func Filter[T any](t *Trie[T], pred func(key []byte, value T) bool) *Trie[T] {
+	if t == nil {
+		return &Trie[T]{}
+	}
+	return orEmpty(filter(t, make([]byte, 0, 64), pred))
+}
+
+func filter[T any](t *Trie[T], path []byte, pred func(key []byte, value T) bool) *Trie[T] {
+	path = append(path, t.Prefix...)
+
+	var res = &Trie[T]{Prefix: t.Prefix}
+	if t.Value != nil && pred(path, *t.Value) {
+		res.Value = t.Value
+	}
+	if t.Children != nil {
+		for i, c := range t.Children {
+			if c != nil {
+				res.setChild(i, filter(c, path, pred))
+			}
+		}
+	}
+	return res.normalized()
+}
+
+// Fold calls f for every entry of trie in lexicographic order of keys, passing result of previous call as acc
+// (init for the first call). Returns result of the last call.
+// Key passed to f is reused - copy it if you need it after f returns.
+//
+//	total := trie.Fold(prices, 0, func(sum int, key []byte, price int) int {
+//		return sum + price
+//	})
+func Fold[T, A any](t *Trie[T], init A, f func(acc A, key []byte, value T) A) A {
+	var acc = init
+	for key, value := range t.All() {
+		acc = f(acc, key, value)
+	}
+	return acc
+}
@@ -0,0 +1,88 @@
+package trie
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMapValues(t *testing.T) {
+	tr := BuildFromMap(map[string]int{
+		"":               0,
+		"/api/user":      1,
+		"/api/user/list": 2,
+		"/api/group":     3,
+	})
+
+	res := MapValues(tr, func(key []byte, value int) string {
+		return fmt.Sprintf("%s=%d", key, value)
+	})
+
+	expected := BuildFromMap(map[string]string{
+		"":               "=0",
+		"/api/user":      "/api/user=1",
+		"/api/user/list": "/api/user/list=2",
+		"/api/group":     "/api/group=3",
+	})
+	if res.String() != expected.String() {
+		t.Errorf("not equal:\nexpected\n%s\ngot\n%s\n", expected, res)
+	}
+	if MapValues((*Trie[int])(nil), func(key []byte, value int) int { return value }).Count() != 0 {
+		t.Errorf("nil trie should give empty result")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	sources := map[string]int{
+		"":               0,
+		"/api/user":      1,
+		"/api/user/list": 2,
+		"/api/group":     3,
+		"/api/groups":    4,
+	}
+	tr := BuildFromMap(sources)
+	original := tr.String()
+
+	res := Filter(tr, func(key []byte, value int) bool {
+		return value%2 == 0
+	})
+	expected := BuildFromMap(map[string]int{"": 0, "/api/user/list": 2, "/api/groups": 4})
+	if res.String() != expected.String() {
+		t.Errorf("not equal:\nexpected\n%s\ngot\n%s\n", expected, res)
+	}
+
+	if res := Filter(tr, func(key []byte, value int) bool { return false }); res.Count() != 0 || res.Prefix != nil {
+		t.Errorf("everything should be filtered out:\n%s", res)
+	}
+	if tr.String() != original {
+		t.Errorf("original trie was modified")
+	}
+}
+
+func TestFold(t *testing.T) {
+	tr := BuildFromMap(map[string]int{"b": 2, "a": 1, "c": 3})
+
+	res := Fold(tr, "", func(acc string, key []byte, value int) string {
+		return acc + fmt.Sprintf("%s%d", key, value)
+	})
+	if res != "a1b2c3" {
+		t.Errorf("got %q expected %q", res, "a1b2c3")
+	}
+}
+
+func ExampleMapValues() {
+	routes := BuildFromMap(map[string]string{
+		"/":      "index",
+		"/about": "about us",
+	})
+
+	titles := MapValues(routes, func(key []byte, page string) string {
+		return strings.ToUpper(page)
+	})
+	for key, title := range titles.All() {
+		fmt.Println(string(key), title)
+	}
+	// Output:
+	// / INDEX
+	// /about ABOUT US
+}
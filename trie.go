@@ -1,5 +1,10 @@
 package trie
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
 // Trie implements sparse radix (Patricia) trie.
 // Makes zero allocation on Get and SearchPrefixIn operations and two allocations per Put
 //
@@ -9,6 +14,11 @@ type Trie[T any] struct {
 	Prefix   []byte
 	Value    *T
 	Children *[256]*Trie[T]
+
+	// mods counts modifications made through this Trie. It is allocated only when it's needed (see Cursor).
+	// Allocation is guarded by watchMutex, so watching can start concurrently with other reads.
+	// Only the node the method was called on counts modifications (usually the root), nested nodes never use it.
+	mods *uint64
 }
 
 // PutString is a convenience method for Put()
@@ -25,7 +35,16 @@ func (t *Trie[T]) PutString(prefix string, value T) {
 // it wouldn't be updated in children automatically. Another problem - node doesn't know it's whole prefix, since
 // it doesn't know it's parent!
 // With current realization caller of Put knows whole prefix and we shouldn't collect it inside. IMHO, much easier.
+//
+// If you need to know whether value existed or to control replacement - use Swap, PutIfAbsent,
+// CompareAndSwap or Update. They get control over the value at the moment it is found, so there is no need
+// to compare values inside trie.
 func (t *Trie[T]) Put(newPrefix []byte, val T) (oldValue T) {
+	t.modified()
+	return t.put(newPrefix, val)
+}
+
+func (t *Trie[T]) put(newPrefix []byte, val T) (oldValue T) {
 	var curPrefix = t.Prefix
 	var ind int
 	for ind < len(curPrefix) && ind < len(newPrefix) && curPrefix[ind] == newPrefix[ind] {
@@ -53,7 +72,7 @@ func (t *Trie[T]) Put(newPrefix []byte, val T) (oldValue T) {
 			} else {
 				// our trie is not empty (we already have value or children)
 				// rest of newPrefix would be added into proper child
-				oldValue = t.getChildOrCreate(newPrefix[ind]).Put(newPrefix[ind:], val)
+				oldValue = t.getChildOrCreate(newPrefix[ind]).put(newPrefix[ind:], val)
 			}
 		}
 	} else {
@@ -67,18 +86,7 @@ func (t *Trie[T]) Put(newPrefix []byte, val T) (oldValue T) {
 
 		// newPrefix shorted than existing or they diverged.
 		// Split current prefix into parts (common part [0:ind] and the rest [ind:])
-		// and place all current fields into newChild
-		var newChild = &Trie[T]{
-			Prefix:   curPrefix[ind:], // take only diverging part of prefix
-			Value:    t.Value,
-			Children: t.Children,
-		}
-
-		// reset current Trie and add newChild
-		t.Prefix = curPrefix[:ind]    // common part (in worst case - it would be empty slice)
-		t.Value = nil                 // no value - it's prefix only
-		t.Children = &[256]*Trie[T]{} // it would have a child anyway
-		t.Children[newChild.Prefix[0]] = newChild
+		t.split(ind)
 
 		// what to do with new value?
 		if ind == len(newPrefix) {
@@ -86,13 +94,49 @@ func (t *Trie[T]) Put(newPrefix []byte, val T) (oldValue T) {
 			t.Value = &val
 		} else {
 			// newPrefix longer than common part. Rest of newPrefix would be set into proper child
-			oldValue = t.getChildOrCreate(newPrefix[ind]).Put(newPrefix[ind:], val)
+			oldValue = t.getChildOrCreate(newPrefix[ind]).put(newPrefix[ind:], val)
 		}
 	}
 
 	return
 }
 
+// modified increments modification counter (if somebody watches it)
+func (t *Trie[T]) modified() {
+	if t.mods != nil {
+		atomic.AddUint64(t.mods, 1)
+	}
+}
+
+// watchMutex guards allocation of modification counters
+var watchMutex sync.Mutex
+
+// watch returns modification counter, allocating it on the first call
+func (t *Trie[T]) watch() *uint64 {
+	watchMutex.Lock()
+	defer watchMutex.Unlock()
+	if t.mods == nil {
+		t.mods = new(uint64)
+	}
+	return t.mods
+}
+
+// split moves all current fields into newChild, that takes only diverging part of prefix (t.Prefix[ind:]).
+// Current Trie keeps common part of prefix and newChild as the only child.
+func (t *Trie[T]) split(ind int) {
+	var newChild = &Trie[T]{
+		Prefix:   t.Prefix[ind:], // take only diverging part of prefix
+		Value:    t.Value,
+		Children: t.Children,
+	}
+
+	// reset current Trie and add newChild
+	t.Prefix = t.Prefix[:ind]     // common part (in worst case - it would be empty slice)
+	t.Value = nil                 // no value - it's prefix only
+	t.Children = &[256]*Trie[T]{} // it would have a child anyway
+	t.Children[newChild.Prefix[0]] = newChild
+}
+
 func (t *Trie[T]) getChildOrCreate(ind byte) *Trie[T] {
 	if t.Children == nil {
 		t.Children = &[256]*Trie[T]{}
@@ -103,6 +147,167 @@ func (t *Trie[T]) getChildOrCreate(ind byte) *Trie[T] {
 	return t.Children[ind]
 }
 
+// DeleteString is a convenience method for Delete
+func (t *Trie[T]) DeleteString(key string) (oldValue T, ok bool) {
+	return t.Delete([]byte(key))
+}
+
+// Delete removes value associated with exactly matching key.
+// Returns removed value and true, or zero value and false if there was no such key.
+//
+// Nodes that become useless (without value and with only one child) are merged back together,
+// so trie keeps the same shape as if the key had never been inserted.
+func (t *Trie[T]) Delete(key []byte) (oldValue T, ok bool) {
+	oldValue, ok = t.delete(key)
+	if ok {
+		t.compact()
+		t.modified()
+	}
+	return oldValue, ok
+}
+
+func (t *Trie[T]) delete(key []byte) (oldValue T, ok bool) {
+	ind := 0
+	for ind < len(t.Prefix) && ind < len(key) && t.Prefix[ind] == key[ind] {
+		ind++
+	}
+
+	if ind < len(t.Prefix) {
+		// prefix didn't match - there is no such key
+		return oldValue, false
+	}
+
+	if ind < len(key) {
+		// not all key bytes matched - continue with proper child
+		if t.Children == nil || t.Children[key[ind]] == nil {
+			return oldValue, false
+		}
+		oldValue, ok = t.Children[key[ind]].delete(key[ind:])
+		if ok {
+			t.compactChild(key[ind])
+		}
+		return oldValue, ok
+	}
+
+	if t.Value == nil {
+		return oldValue, false
+	}
+
+	oldValue = *t.Value
+	t.Value = nil
+	return oldValue, true
+}
+
+// DeletePrefixString is a convenience method for DeletePrefix
+func (t *Trie[T]) DeletePrefixString(mask string) int {
+	return t.DeletePrefix([]byte(mask))
+}
+
+// DeletePrefix removes all values whose prefixes include mask (value for mask itself included).
+// Returns amount of removed values.
+//
+//	tr := {"": v0, "/user/": v1, "/user/list": v2, "/group/": v3}.
+//
+//	tr.DeletePrefix("/user")
+//	-> 2, {"": v0, "/group/": v3}
+func (t *Trie[T]) DeletePrefix(mask []byte) int {
+	count := t.deletePrefix(mask)
+	if count > 0 {
+		t.compact()
+		t.modified()
+	}
+	return count
+}
+
+func (t *Trie[T]) deletePrefix(mask []byte) int {
+	var ind = 0
+	for ind < len(mask) && ind < len(t.Prefix) && mask[ind] == t.Prefix[ind] {
+		ind++
+	}
+
+	if ind == len(mask) {
+		// complete match for mask - whole trie should be dropped
+		count := t.Count()
+		t.Value = nil
+		t.Children = nil
+		return count
+	}
+
+	if ind < len(t.Prefix) || t.Children == nil || t.Children[mask[ind]] == nil {
+		// mask and t.Prefix diverged or there is no such child
+		return 0
+	}
+
+	count := t.Children[mask[ind]].deletePrefix(mask[ind:])
+	if count > 0 {
+		t.compactChild(mask[ind])
+	}
+	return count
+}
+
+// compact restores root Trie after deletion.
+// Empty root resets prefix, and root without value merges with it's only child.
+func (t *Trie[T]) compact() {
+	if t.Value != nil {
+		return
+	}
+	child, count := t.onlyChild()
+	switch count {
+	case 0:
+		t.Prefix = nil
+		t.Children = nil
+	case 1:
+		t.mergeWith(child)
+	}
+}
+
+// compactChild drops child with specified index if it became empty,
+// or merges it with it's own child, if it has no value and only one child left.
+func (t *Trie[T]) compactChild(ind byte) {
+	var c = t.Children[ind]
+	if c.Value != nil {
+		return
+	}
+	grandChild, count := c.onlyChild()
+	switch count {
+	case 0:
+		t.Children[ind] = nil
+		if _, count := t.onlyChild(); count == 0 {
+			t.Children = nil
+		}
+	case 1:
+		c.mergeWith(grandChild)
+	}
+}
+
+// onlyChild returns amount of children (counting stops at 2) and the child itself, if it is the only one.
+func (t *Trie[T]) onlyChild() (child *Trie[T], count int) {
+	if t.Children == nil {
+		return nil, 0
+	}
+	for i := range t.Children {
+		if t.Children[i] != nil {
+			if count++; count > 1 {
+				return nil, count
+			}
+			child = t.Children[i]
+		}
+	}
+	return child, count
+}
+
+// mergeWith takes all fields of child and concatenates prefixes.
+// Prefix is always copied, because it can share underlying array with other nodes or with caller's key.
+func (t *Trie[T]) mergeWith(child *Trie[T]) {
+	var prefix = make([]byte, len(t.Prefix)+len(child.Prefix))
+	copy(prefix, t.Prefix)
+	copy(prefix[len(t.Prefix):], child.Prefix)
+
+	t.Prefix = prefix
+	t.Value = child.Value
+	t.Children = child.Children
+}
+
 // GetByString is a convenience method for Get
 func (t *Trie[T]) GetByString(key string) (T, bool) {
 	return t.Get([]byte(key))
@@ -190,6 +395,83 @@ func (t *Trie[T]) SearchPrefixIn(input []byte) (value T, prefixLen int, ok bool)
 	return value, 0, false
 }
 
+// SearchAllPrefixesInString is a convenience method for SearchAllPrefixesIn
+func (t *Trie[T]) SearchAllPrefixesInString(str string, callback func(value T, prefixLen int) bool) {
+	t.SearchAllPrefixesIn([]byte(str), callback)
+}
+
+// SearchAllPrefixesIn calls callback for every stored key that is a prefix of input,
+// from the shortest to the longest. Search stops if callback returns false.
+// Makes no allocations (unlike Ancestors, which returns iterator).
+//
+//	tr := {"a": v0, "ab": v1, "abc": v2}
+//
+//	tr.SearchAllPrefixesIn("abd", callback)
+//	-> callback(v0, 1), callback(v1, 2)
+func (t *Trie[T]) SearchAllPrefixesIn(input []byte, callback func(value T, prefixLen int) bool) {
+	var node, offset = t, 0
+	for node != nil {
+		ind := 0
+		for ind < len(node.Prefix) && offset+ind < len(input) && node.Prefix[ind] == input[offset+ind] {
+			ind++
+		}
+		if ind < len(node.Prefix) {
+			// prefix didn't match
+			return
+		}
+		offset += ind
+
+		if node.Value != nil && !callback(*node.Value, offset) {
+			return
+		}
+		if offset == len(input) {
+			return
+		}
+		node = node.child(int(input[offset]))
+	}
+}
+
+// SearchShortestPrefixInString is a convenience method for SearchShortestPrefixIn
+func (t *Trie[T]) SearchShortestPrefixInString(str string) (value T, prefixLen int, ok bool) {
+	return t.SearchShortestPrefixIn([]byte(str))
+}
+
+// SearchShortestPrefixIn searches the shortest matching prefix in input bytes.
+// Works like SearchPrefixIn, but stops at the first found key.
+func (t *Trie[T]) SearchShortestPrefixIn(input []byte) (value T, prefixLen int, ok bool) {
+	t.SearchAllPrefixesIn(input, func(v T, l int) bool {
+		value, prefixLen, ok = v, l, true
+		return false
+	})
+	return value, prefixLen, ok
+}
+
+// searchPrefixPartial works like SearchPrefixIn (but ignores empty key) and also reports
+// whether input ends in the middle of some longer key - so result can change if input is continued.
+func (t *Trie[T]) searchPrefixPartial(input []byte) (value T, prefixLen int, ok bool, more bool) {
+	var node, offset = t, 0
+	for node != nil {
+		ind := 0
+		for ind < len(node.Prefix) && offset+ind < len(input) && node.Prefix[ind] == input[offset+ind] {
+			ind++
+		}
+		if ind < len(node.Prefix) {
+			// prefix didn't match, but it still can if input ended
+			return value, prefixLen, ok, offset+ind == len(input)
+		}
+		offset += ind
+
+		if node.Value != nil && offset > 0 {
+			value, prefixLen, ok = *node.Value, offset, true
+		}
+		if offset == len(input) {
+			return value, prefixLen, ok, nextChild(node, 0) >= 0
+		}
+		node = node.child(int(input[offset]))
+	}
+	return value, prefixLen, ok, false
+}
+
 // Iterate calls callback for each value stored in trie
 //
 // Not thread safe.
@@ -197,15 +479,15 @@ func (t *Trie[T]) SearchPrefixIn(input []byte) (value T, prefixLen int, ok bool)
 // Also prefix's underlying array would change on every call - so you can't rely on it after callback finishes
 // (e.g. you should not pass it to another goroutine without copying)
 //
-// It seems like the only possible iteration order is by key (prefix):
+// Iteration order is lexicographic by key (prefix), the same as for All:
 //
 //	0x1, 0x1 0x1, 0x1 0x2, 0x1 0x3, 0x2, 0x2 0x1, 0x2 0x2, etc...
 //
-// But it's not guarantied. You shouldn't rely on it!
-//
 // Can be used in combination with SubTrie:
 //
 //	tr.SubTrie(mask).Iterate(func...)
+//
+// If you need to stop iteration early - use All or WithPrefix with for range loop.
 func (t *Trie[T]) Iterate(callback func(prefix []byte, value T)) {
 	t.iterate(make([]byte, 0, 1024), callback)
 }
@@ -257,7 +539,7 @@ func (t *Trie[T]) subTrie(mask []byte, keepPrefix bool, originalMask []byte, ori
 		if keepPrefix {
 			res.Prefix = make([]byte, len(originalMask[:originalMaskInd])+len(t.Prefix))
 			copy(res.Prefix, originalMask[:originalMaskInd])
-			copy(res.Prefix[ind:], t.Prefix)
+			copy(res.Prefix[originalMaskInd:], t.Prefix)
 		} else {
 			// copy the rest of t.Prefix (would be empty if t.Prefix match also complete)
 			res.Prefix = t.Prefix[ind:]
@@ -292,6 +574,8 @@ func (t *Trie[T]) GetAllByString(str string) []T {
 //
 //	tr.GetAll("/user/list", false)
 //	-> [v0, v1, v2]
+//
+// Use AppendAll to reuse result slice, or Ancestors to get matched prefixes too.
 func (t *Trie[T]) GetAll(mask []byte) []T {
 	var ind = 0
 	for ind < len(mask) && ind < len(t.Prefix) && mask[ind] == t.Prefix[ind] {
@@ -314,6 +598,33 @@ func (t *Trie[T]) GetAll(mask []byte) []T {
 	}
 }
 
+// AppendAll appends to dst all values whose prefixes are subsets of mask (like GetAll) and returns extended slice.
+// Makes no allocations if dst has enough capacity.
+func (t *Trie[T]) AppendAll(dst []T, mask []byte) []T {
+	var node = t
+	for node != nil {
+		var ind = 0
+		for ind < len(mask) && ind < len(node.Prefix) && mask[ind] == node.Prefix[ind] {
+			ind++
+		}
+		if ind < len(node.Prefix) {
+			// doesn't match current prefix
+			return dst
+		}
+
+		if node.Value != nil {
+			dst = append(dst, *node.Value)
+		}
+		if ind == len(mask) {
+			return dst
+		}
+
+		mask = mask[ind:]
+		node = node.child(int(mask[0]))
+	}
+	return dst
+}
+
 // Count returns amount of values (non nil) stored in all nodes of trie.
 func (t *Trie[T]) Count() int {
 	if t == nil {
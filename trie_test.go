@@ -154,6 +154,68 @@ func TestTrie_SearchPrefixIn(t *testing.T) {
 	}
 }
 
+func TestTrie_SearchAllPrefixesIn(t *testing.T) {
+	tr := BuildFromMap(map[string]string{
+		"":       "root",
+		"a":      "a",
+		"ab":     "ab",
+		"abc":    "abc",
+		"abcdef": "abcdef",
+		"b":      "b",
+	})
+
+	type found struct {
+		Value     string
+		PrefixLen int
+	}
+
+	var inputs = map[string][]found{
+		"abcde":  {{"root", 0}, {"a", 1}, {"ab", 2}, {"abc", 3}},
+		"abcdef": {{"root", 0}, {"a", 1}, {"ab", 2}, {"abc", 3}, {"abcdef", 6}},
+		"bcd":    {{"root", 0}, {"b", 1}},
+		"c":      {{"root", 0}},
+	}
+
+	for input, expected := range inputs {
+		var got []found
+		tr.SearchAllPrefixesInString(input, func(value string, prefixLen int) bool {
+			got = append(got, found{value, prefixLen})
+			return true
+		})
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%q: got %v expected %v", input, got, expected)
+		}
+
+		got = got[:0]
+		tr.SearchAllPrefixesInString(input, func(value string, prefixLen int) bool {
+			got = append(got, found{value, prefixLen})
+			return len(got) < 2
+		})
+		if len(got) != min(2, len(expected)) {
+			t.Errorf("%q: search wasn't stopped: %v", input, got)
+		}
+	}
+
+	noRoot := BuildPrefixesOnly("ab", "abc", "b")
+	if _, l, ok := noRoot.SearchShortestPrefixInString("abcd"); !ok || l != 2 {
+		t.Errorf("got (%d, %t) expected (2, true)", l, ok)
+	}
+	if _, _, ok := noRoot.SearchShortestPrefixInString("a"); ok {
+		t.Errorf("found prefix that is not stored")
+	}
+
+	var input = []byte("abcdefgh")
+	var count = 0
+	if allocs := testing.AllocsPerRun(100, func() {
+		tr.SearchAllPrefixesIn(input, func(value string, prefixLen int) bool {
+			count++
+			return true
+		})
+	}); allocs != 0 {
+		t.Errorf("SearchAllPrefixesIn makes %v allocations", allocs)
+	}
+}
+
 func TestTrie_GetAll(t *testing.T) {
 	tr := BuildFromMap(map[string]string{
 		"":                "root",
@@ -211,6 +273,8 @@ func TestTrie_SubTrie(t *testing.T) {
 		{"/test/", false}:            {ok: false},
 		{"/api/group", true}:         {ok: true, rootPrefix: []byte("/api/group/")},
 		{"/api/articles/test", true}: {ok: false},
+		{"/api/user/", true}:         {ok: true, rootPrefix: []byte("/api/user/list")},
+		{"/api/art", true}:           {ok: true, rootPrefix: []byte("/api/articles/")},
 	}
 
 	for args, res := range selectors {
@@ -281,6 +345,122 @@ func TestTrie_Count(t *testing.T) {
 	}
 }
 
+func TestTrie_Delete(t *testing.T) {
+	sources := map[string]string{
+		"":                       "root",
+		"/api/user":              "user",
+		"/api/user/list":         "users list",
+		"/api/group/":            "group",
+		"/api/group/list":        "groups list",
+		"/api/articles/list":     "articles list",
+		"/api/articles/raw/list": "raw articles list",
+	}
+
+	tr := BuildFromMap(sources)
+
+	if _, ok := tr.DeleteString("/api/articles/"); ok {
+		t.Errorf("deleted key that wasn't stored")
+	}
+	if _, ok := tr.DeleteString("/api/unknown"); ok {
+		t.Errorf("deleted key that wasn't stored")
+	}
+
+	for _, key := range []string{"/api/user", "", "/api/articles/raw/list", "/api/group/list", "/api/group/", "/api/user/list", "/api/articles/list"} {
+		old, ok := tr.DeleteString(key)
+		if !ok || old != sources[key] {
+			t.Errorf("%q: got (%q, %t) expected (%q, true)", key, old, ok, sources[key])
+		}
+		if _, ok := tr.DeleteString(key); ok {
+			t.Errorf("%q: deleted twice", key)
+		}
+		delete(sources, key)
+
+		expected := BuildFromMap(sources)
+		if tr.String() != expected.String() {
+			t.Fatalf("%q: not equal:\nexpected\n%s\ngot\n%s\n", key, expected, tr)
+		}
+		if tr.Count() != len(sources) {
+			t.Errorf("%q: got count %d expected %d", key, tr.Count(), len(sources))
+		}
+	}
+
+	if !reflect.DeepEqual(tr, &Trie[string]{}) {
+		t.Errorf("trie should be empty, got\n%s", tr)
+	}
+}
+
+func TestTrie_Delete__Random(t *testing.T) {
+	const letterBytes = "abc"
+	sources := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		var b = make([]byte, rand.Intn(6))
+		for j := range b {
+			b[j] = letterBytes[rand.Intn(len(letterBytes))]
+		}
+		sources[string(b)] = i
+	}
+
+	tr := BuildFromMap(sources)
+	for key := range sources {
+		if _, ok := tr.DeleteString(key); !ok {
+			t.Fatalf("%q: not deleted", key)
+		}
+		delete(sources, key)
+
+		expected := BuildFromMap(sources)
+		if tr.String() != expected.String() {
+			t.Fatalf("%q: not equal:\nexpected\n%s\ngot\n%s\n", key, expected, tr)
+		}
+	}
+}
+
+func TestTrie_DeletePrefix(t *testing.T) {
+	sources := map[string]string{
+		"":                       "root",
+		"/api/user":              "user",
+		"/api/user/list":         "users list",
+		"/api/group/":            "group",
+		"/api/group/list":        "groups list",
+		"/api/articles/list":     "articles list",
+		"/api/articles/raw/list": "raw articles list",
+	}
+
+	tests := []struct {
+		mask     string
+		count    int
+		expected []string
+	}{
+		{"/test", 0, []string{"", "/api/user", "/api/user/list", "/api/group/", "/api/group/list", "/api/articles/list", "/api/articles/raw/list"}},
+		{"/api/articles/", 2, []string{"", "/api/user", "/api/user/list", "/api/group/", "/api/group/list"}},
+		{"/api/user/", 1, []string{"", "/api/user", "/api/group/", "/api/group/list"}},
+		{"/api/g", 2, []string{"", "/api/user"}},
+		{"", 2, nil},
+	}
+
+	tr := BuildFromMap(sources)
+	for _, tt := range tests {
+		if count := tr.DeletePrefixString(tt.mask); count != tt.count {
+			t.Errorf("%q: got %d expected %d", tt.mask, count, tt.count)
+		}
+
+		expected := BuildPrefixesOnly(tt.expected...)
+		var got []string
+		tr.Iterate(func(prefix []byte, value string) {
+			got = append(got, string(prefix))
+		})
+		var want []string
+		expected.Iterate(func(prefix []byte, value struct{}) {
+			want = append(want, string(prefix))
+		})
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %q expected %q", tt.mask, got, want)
+		}
+		if tr.Count() != len(tt.expected) {
+			t.Errorf("%q: got count %d expected %d", tt.mask, tr.Count(), len(tt.expected))
+		}
+	}
+}
+
 // BenchmarkTrie_Put-8   	 1000000	      1268 ns/op	     509 B/op	       2 allocs/op
 func BenchmarkTrie_Put(b *testing.B) {
 	b.ReportAllocs()
@@ -0,0 +1,115 @@
+package trie
+
+// updateOp tells update what to do with found value
+type updateOp int
+
+const (
+	updateNone   updateOp = 0 // leave trie as is
+	updateStore  updateOp = 1 // store new value
+	updateDelete updateOp = 2 // delete value (if it exists)
+)
+
+// Update calls fn with value associated with key (and true), or with zero value and false if there is no such key.
+// If fn returns keep == true - returned value is stored under key, otherwise key is deleted.
+// Makes single traversal of trie. Returns value stored under key after update and whether it exists.
+//
+//	counters.Update(key, func(old int, exists bool) (int, bool) {
+//		return old + 1, true
+//	})
+func (t *Trie[T]) Update(key []byte, fn func(old T, exists bool) (newValue T, keep bool)) (value T, ok bool) {
+	return t.updateWith(key, func(old T, exists bool) (T, updateOp) {
+		newValue, keep := fn(old, exists)
+		if keep {
+			return newValue, updateStore
+		}
+		return newValue, updateDelete
+	})
+}
+
+// PutIfAbsent stores value only if there is no value for key yet.
+// Returns existing value and true, or stored value and false.
+func (t *Trie[T]) PutIfAbsent(key []byte, value T) (actual T, loaded bool) {
+	t.updateWith(key, func(old T, exists bool) (T, updateOp) {
+		if exists {
+			actual, loaded = old, true
+			return old, updateNone
+		}
+		actual = value
+		return value, updateStore
+	})
+	return actual, loaded
+}
+
+// Swap stores value under key and returns previous value and whether it existed.
+// Unlike Put it allows to distinguish stored zero value from absence of value.
+func (t *Trie[T]) Swap(key []byte, value T) (previous T, existed bool) {
+	t.updateWith(key, func(old T, exists bool) (T, updateOp) {
+		previous, existed = old, exists
+		return value, updateStore
+	})
+	return previous, existed
+}
+
+// CompareAndSwap stores newValue under key only if there is a value for key and eq(value, oldValue) is true.
+// Returns whether value was swapped.
+func (t *Trie[T]) CompareAndSwap(key []byte, oldValue, newValue T, eq func(a, b T) bool) (swapped bool) {
+	t.updateWith(key, func(old T, exists bool) (T, updateOp) {
+		if exists && eq(old, oldValue) {
+			swapped = true
+			return newValue, updateStore
+		}
+		return old, updateNone
+	})
+	return swapped
+}
+
+func (t *Trie[T]) updateWith(key []byte, fn func(old T, exists bool) (T, updateOp)) (value T, ok bool) {
+	value, ok, changed := t.update(key, fn)
+	if changed {
+		t.compact()
+		t.modified()
+	}
+	return value, ok
+}
+
+// update finds value for key and replaces or deletes it during the same traversal.
+// If there is no value - new one is put starting from the node where search stopped.
+func (t *Trie[T]) update(key []byte, fn func(old T, exists bool) (T, updateOp)) (value T, ok bool, changed bool) {
+	ind := 0
+	for ind < len(t.Prefix) && ind < len(key) && t.Prefix[ind] == key[ind] {
+		ind++
+	}
+
+	if ind == len(t.Prefix) && ind < len(key) && t.Children != nil && t.Children[key[ind]] != nil {
+		// continue with proper child
+		value, ok, changed = t.Children[key[ind]].update(key[ind:], fn)
+		if changed && !ok {
+			// value could be deleted
+			t.compactChild(key[ind])
+		}
+		return value, ok, changed
+	}
+
+	if ind == len(t.Prefix) && ind == len(key) && t.Value != nil {
+		// exact match
+		newValue, op := fn(*t.Value, true)
+		switch op {
+		case updateStore:
+			t.Value = &newValue
+			return newValue, true, true
+		case updateDelete:
+			t.Value = nil
+			return value, false, true
+		default:
+			return *t.Value, true, false
+		}
+	}
+
+	// there is no such key
+	newValue, op := fn(value, false)
+	if op != updateStore {
+		return value, false, false
+	}
+	t.put(key, newValue)
+	return newValue, true, true
+}
@@ -0,0 +1,113 @@
+package trie
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTrie_Update(t *testing.T) {
+	tr := BuildFromMap(map[string]int{
+		"/api/user":      1,
+		"/api/user/list": 2,
+		"/api/group":     3,
+	})
+
+	increment := func(old int, exists bool) (int, bool) {
+		return old + 1, true
+	}
+
+	if v, ok := tr.Update([]byte("/api/user"), increment); !ok || v != 2 {
+		t.Errorf("got (%d, %t) expected (2, true)", v, ok)
+	}
+	if v, ok := tr.Update([]byte("/api/articles"), increment); !ok || v != 1 {
+		t.Errorf("got (%d, %t) expected (1, true)", v, ok)
+	}
+	if v, ok := tr.Update([]byte("/api"), increment); !ok || v != 1 {
+		t.Errorf("got (%d, %t) expected (1, true)", v, ok)
+	}
+
+	// delete by returning keep == false
+	for _, key := range []string{"/api", "/api/user", "/api/unknown"} {
+		if _, ok := tr.Update([]byte(key), func(old int, exists bool) (int, bool) { return 0, false }); ok {
+			t.Errorf("%q: value should be deleted", key)
+		}
+	}
+
+	expected := BuildFromMap(map[string]int{
+		"/api/user/list": 2,
+		"/api/group":     3,
+		"/api/articles":  1,
+	})
+	if tr.String() != expected.String() {
+		t.Errorf("not equal:\nexpected\n%s\ngot\n%s\n", expected, tr)
+	}
+}
+
+func TestTrie_Swap(t *testing.T) {
+	tr := &Trie[int]{}
+
+	if old, existed := tr.Swap([]byte("zero"), 0); existed || old != 0 {
+		t.Errorf("got (%d, %t) expected (0, false)", old, existed)
+	}
+	if old, existed := tr.Swap([]byte("zero"), 1); !existed || old != 0 {
+		t.Errorf("got (%d, %t) expected (0, true)", old, existed)
+	}
+
+	if actual, loaded := tr.PutIfAbsent([]byte("zero"), 2); !loaded || actual != 1 {
+		t.Errorf("got (%d, %t) expected (1, true)", actual, loaded)
+	}
+	if actual, loaded := tr.PutIfAbsent([]byte("ze"), 2); loaded || actual != 2 {
+		t.Errorf("got (%d, %t) expected (2, false)", actual, loaded)
+	}
+
+	eq := func(a, b int) bool { return a == b }
+	if tr.CompareAndSwap([]byte("zero"), 0, 5, eq) {
+		t.Errorf("swapped with wrong old value")
+	}
+	if tr.CompareAndSwap([]byte("unknown"), 0, 5, eq) {
+		t.Errorf("swapped absent value")
+	}
+	if !tr.CompareAndSwap([]byte("zero"), 1, 5, eq) {
+		t.Errorf("not swapped")
+	}
+
+	if v, _ := tr.GetByString("zero"); v != 5 {
+		t.Errorf("got %d expected 5", v)
+	}
+	if v, _ := tr.GetByString("ze"); v != 2 {
+		t.Errorf("got %d expected 2", v)
+	}
+	if _, ok := tr.GetByString("unknown"); ok {
+		t.Errorf("CompareAndSwap shouldn't add values")
+	}
+}
+
+func TestTrie_PutIfAbsent__KeepsCursor(t *testing.T) {
+	tr := BuildPrefixesOnly("a", "b")
+	c := tr.Cursor()
+	c.First()
+	tr.PutIfAbsent([]byte("a"), struct{}{})
+	if !c.Valid() {
+		t.Errorf("PutIfAbsent of existing key shouldn't modify trie")
+	}
+}
+
+func ExampleTrie_Update() {
+	words := &Trie[int]{}
+	for _, w := range []string{"apple", "banana", "apple", "cherry", "apple", "banana"} {
+		words.Update([]byte(w), func(old int, exists bool) (int, bool) {
+			return old + 1, true
+		})
+	}
+	// drop rare words
+	words.Update([]byte("cherry"), func(old int, exists bool) (int, bool) {
+		return old, old > 1
+	})
+
+	for word, count := range words.All() {
+		fmt.Println(string(word), count)
+	}
+	// Output:
+	// apple 3
+	// banana 2
+}
@@ -0,0 +1,122 @@
+package trie
+
+import (
+	"bytes"
+	"iter"
+)
+
+// View provides access to entries of trie under prefix with keys relative to that prefix.
+// Unlike SubTrie it doesn't detach anything: all operations are translated into operations
+// on the parent trie with prefix prepended, so changes are always visible from both sides.
+//
+//	tr := {"/api/user": v1}
+//	users := tr.View("/api/user")
+//
+//	users.Put("/list", v2)
+//	-> tr == {"/api/user": v1, "/api/user/list": v2}
+//
+// Not thread safe.
+type View[T any] struct {
+	trie   *Trie[T]
+	prefix []byte
+}
+
+// View returns live view of entries under prefix (see View type).
+func (t *Trie[T]) View(prefix []byte) View[T] {
+	return View[T]{trie: t, prefix: bytes.Clone(prefix)}
+}
+
+// Prefix returns prefix of the view. It shouldn't be modified.
+func (v View[T]) Prefix() []byte {
+	return v.prefix
+}
+
+// View returns nested view with keys relative to Prefix()+prefix.
+func (v View[T]) View(prefix []byte) View[T] {
+	return View[T]{trie: v.trie, prefix: v.key(prefix)}
+}
+
+// Get searches for exactly matching key under view's prefix.
+// Like Trie.Get it makes no allocations.
+func (v View[T]) Get(key []byte) (res T, found bool) {
+	node, off, ok := v.trie.locate(v.prefix)
+	if !ok {
+		return res, false
+	}
+
+	// the rest of node's prefix should match key
+	var rest = node.Prefix[off:]
+	if !bytes.HasPrefix(key, rest) {
+		return res, false
+	}
+	key = key[len(rest):]
+
+	if len(key) == 0 {
+		if node.Value == nil {
+			return res, false
+		}
+		return *node.Value, true
+	}
+	if child := node.child(int(key[0])); child != nil {
+		return child.Get(key)
+	}
+	return res, false
+}
+
+// Put adds new entry into parent trie with key prefixed by view's prefix.
+func (v View[T]) Put(key []byte, value T) (oldValue T) {
+	return v.trie.Put(v.key(key), value)
+}
+
+// Delete removes entry with key prefixed by view's prefix from parent trie.
+func (v View[T]) Delete(key []byte) (oldValue T, ok bool) {
+	return v.trie.Delete(v.key(key))
+}
+
+// Clear removes all entries under view's prefix from parent trie. Returns amount of removed entries.
+func (v View[T]) Clear() int {
+	return v.trie.DeletePrefix(v.prefix)
+}
+
+// All returns iterator over entries under view's prefix with relative keys.
+// Order and key reuse are the same as for Trie.All.
+func (v View[T]) All() iter.Seq2[[]byte, T] {
+	return func(yield func([]byte, T) bool) {
+		if node, off, ok := v.trie.locate(v.prefix); ok {
+			var rest = &Trie[T]{Prefix: node.Prefix[off:], Value: node.Value, Children: node.Children}
+			rest.all(make([]byte, 0, 64), yield)
+		}
+	}
+}
+
+// key returns new slice with view's prefix and key
+func (v View[T]) key(key []byte) []byte {
+	var res = make([]byte, len(v.prefix)+len(key))
+	copy(res, v.prefix)
+	copy(res[len(v.prefix):], key)
+	return res
+}
+
+// locate searches node where mask ends. Returns node and amount of node's prefix bytes that belong to mask.
+func (t *Trie[T]) locate(mask []byte) (node *Trie[T], off int, ok bool) {
+	node = t
+	for {
+		ind := 0
+		for ind < len(mask) && ind < len(node.Prefix) && mask[ind] == node.Prefix[ind] {
+			ind++
+		}
+
+		if ind == len(mask) {
+			return node, ind, true
+		}
+		if ind < len(node.Prefix) {
+			// mask and node's prefix diverged
+			return nil, 0, false
+		}
+
+		if node = node.child(int(mask[ind])); node == nil {
+			return nil, 0, false
+		}
+		mask = mask[ind:]
+	}
+}
@@ -0,0 +1,81 @@
+package trie
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestView(t *testing.T) {
+	tr := BuildFromMap(map[string]string{
+		"/":              "index",
+		"/api/user":      "user",
+		"/api/user/list": "users list",
+		"/api/group":     "group",
+	})
+
+	users := tr.View([]byte("/api/us"))
+
+	if v, ok := users.Get([]byte("er/list")); !ok || v != "users list" {
+		t.Errorf("got (%q, %t) expected (%q, true)", v, ok, "users list")
+	}
+	if _, ok := users.Get([]byte("")); ok {
+		t.Errorf("found value for prefix itself")
+	}
+	if _, ok := users.Get([]byte("e")); ok {
+		t.Errorf("found value for part of node's prefix")
+	}
+
+	// Put splits node, that contains view's prefix. View should continue to work.
+	users.Put(nil, "us")
+	users.Put([]byte("ers"), "users")
+	if v, ok := users.Get(nil); !ok || v != "us" {
+		t.Errorf("got (%q, %t) expected (%q, true)", v, ok, "us")
+	}
+	if v, ok := tr.GetByString("/api/users"); !ok || v != "users" {
+		t.Errorf("got (%q, %t) expected (%q, true)", v, ok, "users")
+	}
+
+	if old, ok := users.Delete([]byte("er")); !ok || old != "user" {
+		t.Errorf("got (%q, %t) expected (%q, true)", old, ok, "user")
+	}
+
+	var keys []string
+	for key := range users.All() {
+		keys = append(keys, string(key))
+	}
+	if expected := []string{"", "er/list", "ers"}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("got %q expected %q", keys, expected)
+	}
+
+	if v, ok := users.View([]byte("er/")).Get([]byte("list")); !ok || v != "users list" {
+		t.Errorf("nested view: got (%q, %t) expected (%q, true)", v, ok, "users list")
+	}
+
+	if count := users.Clear(); count != 3 {
+		t.Errorf("got %d expected 3", count)
+	}
+	for key := range users.All() {
+		t.Errorf("unexpected key %q after Clear", key)
+	}
+	if exp := BuildFromMap(map[string]string{"/": "index", "/api/group": "group"}); tr.String() != exp.String() {
+		t.Errorf("not equal:\nexpected\n%s\ngot\n%s\n", exp, tr)
+	}
+}
+
+func ExampleView() {
+	routes := &Trie[string]{}
+	routes.PutString("/", "index")
+
+	users := routes.View([]byte("/api/users"))
+	users.Put([]byte(""), "list users")
+	users.Put([]byte("/new"), "create user")
+
+	for key, value := range routes.All() {
+		fmt.Println(string(key), value)
+	}
+	// Output:
+	// / index
+	// /api/users list users
+	// /api/users/new create user
+}
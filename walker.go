@@ -0,0 +1,110 @@
+package trie
+
+// Walker matches input against stored keys incrementally: it is fed one byte at a time and keeps current
+// position in trie, so nothing is searched from the root again after every byte.
+// It suits interactive input (line editors, autocompletion) and streaming parsers.
+//
+//	tr := {"go": v0, "golang": v1, "gopher": v2}
+//
+//	w := tr.Walker()
+//	w.Step('g'), w.Step('o') -> true, true
+//	w.Value()                -> v0, true
+//	w.NextBytes()            -> "lp"
+//	w.Step('x')              -> false (no key starts with "gox")
+//
+// Walker should be reset after trie is modified. Not thread safe.
+type Walker[T any] struct {
+	root *Trie[T]
+	node *Trie[T]
+	// offset is an amount of matched bytes of node.Prefix
+	offset int
+	depth  int
+	dead   bool
+}
+
+// Walker creates new Walker positioned at the root of trie.
+func (t *Trie[T]) Walker() *Walker[T] {
+	return &Walker[T]{root: t, node: t}
+}
+
+// Reset moves walker back to the root (as if no bytes were fed).
+func (w *Walker[T]) Reset() {
+	w.node, w.offset, w.depth, w.dead = w.root, 0, 0, false
+}
+
+// Step feeds next byte of input. Returns false if there are no keys starting with bytes fed so far.
+// After that walker stays dead (all following steps return false) until Reset.
+func (w *Walker[T]) Step(b byte) (alive bool) {
+	if w.dead || w.node == nil {
+		w.dead = true
+		return false
+	}
+
+	if w.offset == len(w.node.Prefix) {
+		// node is passed - go to the proper child (it's prefix starts with b)
+		var next = w.node.child(int(b))
+		if next == nil {
+			w.dead = true
+			return false
+		}
+		w.node, w.offset = next, 0
+	}
+
+	if w.node.Prefix[w.offset] != b {
+		w.dead = true
+		return false
+	}
+	w.offset++
+	w.depth++
+	return true
+}
+
+// Value returns value of the key that consists of bytes fed so far (if there is one).
+func (w *Walker[T]) Value() (value T, ok bool) {
+	if w.dead || w.node == nil || w.offset < len(w.node.Prefix) || w.node.Value == nil {
+		return value, false
+	}
+	return *w.node.Value, true
+}
+
+// Depth returns amount of successfully fed bytes (it is not changed by failed step).
+func (w *Walker[T]) Depth() int {
+	return w.depth
+}
+
+// Alive reports whether bytes fed so far are a prefix of some key (or the key itself).
+func (w *Walker[T]) Alive() bool {
+	if w.dead || w.node == nil {
+		return false
+	}
+	// only the root of empty trie has no value and no children
+	return w.offset < len(w.node.Prefix) || w.node.Value != nil || nextChild(w.node, 0) >= 0
+}
+
+// CanContinue reports whether some key is longer than bytes fed so far (so the next step can succeed).
+func (w *Walker[T]) CanContinue() bool {
+	if w.dead || w.node == nil {
+		return false
+	}
+	return w.offset < len(w.node.Prefix) || nextChild(w.node, 0) >= 0
+}
+
+// NextBytes returns all bytes that can be fed with the next successful step (in ascending order).
+func (w *Walker[T]) NextBytes() []byte {
+	return w.AppendNextBytes(nil)
+}
+
+// AppendNextBytes appends to dst all bytes that can be fed with the next successful step (in ascending order).
+// Can be used to avoid allocations on every step.
+func (w *Walker[T]) AppendNextBytes(dst []byte) []byte {
+	if w.dead || w.node == nil {
+		return dst
+	}
+	if w.offset < len(w.node.Prefix) {
+		return append(dst, w.node.Prefix[w.offset])
+	}
+	for i := nextChild(w.node, 0); i >= 0; i = nextChild(w.node, i+1) {
+		dst = append(dst, byte(i))
+	}
+	return dst
+}
@@ -0,0 +1,141 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestWalker__Random(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		var tr = &Trie[int]{}
+		var keys [][]byte
+		for j := 0; j < rand.Intn(10); j++ {
+			var key = make([]byte, rand.Intn(5))
+			for k := range key {
+				key[k] = "abc"[rand.Intn(3)]
+			}
+			tr.Put(key, j)
+			keys = append(keys, key)
+		}
+
+		var input = make([]byte, rand.Intn(6))
+		for k := range input {
+			input[k] = "abcd"[rand.Intn(4)]
+		}
+
+		var w = tr.Walker()
+		var alive = w.Alive()
+		for pos := 0; pos <= len(input); pos++ {
+			if pos > 0 {
+				alive = w.Step(input[pos-1]) && alive
+			}
+			var fed = input[:pos]
+
+			var expectedAlive, expectedContinue = false, false
+			var next = map[byte]bool{}
+			for _, key := range keys {
+				if bytes.HasPrefix(key, fed) {
+					expectedAlive = true
+					if len(key) > len(fed) {
+						expectedContinue = true
+						next[key[len(fed)]] = true
+					}
+				}
+			}
+			var expectedNext []byte
+			for b := 0; b < 256; b++ {
+				if next[byte(b)] {
+					expectedNext = append(expectedNext, byte(b))
+				}
+			}
+
+			if alive != expectedAlive || w.Alive() != expectedAlive {
+				t.Fatalf("%v %q: alive %v expected %v", tr, fed, alive, expectedAlive)
+			}
+			if !alive {
+				break
+			}
+			if w.Depth() != pos {
+				t.Errorf("%v %q: depth %d expected %d", tr, fed, w.Depth(), pos)
+			}
+			if w.CanContinue() != expectedContinue {
+				t.Errorf("%v %q: can continue %v expected %v", tr, fed, w.CanContinue(), expectedContinue)
+			}
+			if !bytes.Equal(w.NextBytes(), expectedNext) {
+				t.Errorf("%v %q: next bytes %q expected %q", tr, fed, w.NextBytes(), expectedNext)
+			}
+			value, ok := w.Value()
+			expectedValue, expectedOk := tr.Get(fed)
+			if value != expectedValue || ok != expectedOk {
+				t.Errorf("%v %q: got (%v, %v) expected (%v, %v)", tr, fed, value, ok, expectedValue, expectedOk)
+			}
+		}
+	}
+}
+
+func TestWalker(t *testing.T) {
+	tr := BuildFromMap(map[string]int{"go": 1, "golang": 2, "gopher": 3})
+	w := tr.Walker()
+
+	for _, b := range []byte("gol") {
+		if !w.Step(b) {
+			t.Fatalf("step %q failed", b)
+		}
+	}
+	if _, ok := w.Value(); ok {
+		t.Errorf("unexpected value")
+	}
+	if w.Step('x') || w.Step('a') || w.Alive() || w.CanContinue() || w.NextBytes() != nil {
+		t.Errorf("walker should stay dead")
+	}
+	if w.Depth() != 3 {
+		t.Errorf("depth %d expected 3", w.Depth())
+	}
+
+	w.Reset()
+	if w.Depth() != 0 || !w.Alive() || string(w.NextBytes()) != "g" {
+		t.Errorf("walker should be reset")
+	}
+
+	var empty *Trie[int]
+	if w := empty.Walker(); w.Alive() || w.Step('a') || w.CanContinue() {
+		t.Errorf("walker of nil trie should be dead")
+	}
+}
+
+func ExampleWalker() {
+	commands := BuildFromMap(map[string]string{
+		"commit":   "record changes",
+		"checkout": "switch branches",
+		"cherry":   "find commits",
+		"clone":    "clone repository",
+	})
+
+	w := commands.Walker()
+	for _, b := range []byte("ch") {
+		w.Step(b)
+	}
+	fmt.Printf("after %q: %q\n", "ch", w.NextBytes())
+
+	for _, b := range []byte("eck") {
+		w.Step(b)
+	}
+	// only one key is possible - complete it
+	var completion strings.Builder
+	for w.CanContinue() {
+		next := w.NextBytes()
+		if len(next) != 1 {
+			break
+		}
+		w.Step(next[0])
+		completion.WriteByte(next[0])
+	}
+	value, _ := w.Value()
+	fmt.Printf("completed %q: %s\n", "check"+completion.String(), value)
+	// Output:
+	// after "ch": "e"
+	// completed "checkout": switch branches
+}